@@ -0,0 +1,234 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Code is a machine-readable reason for why a Value failed validation.
+type Code string
+
+const (
+	// CodeTypeMismatch is used when the input can't be parsed into the type of the Result
+	CodeTypeMismatch Code = "type_mismatch"
+
+	// CodeOutOfRange is used when a number is too large or too small for the type of the Result
+	CodeOutOfRange Code = "out_of_range"
+
+	// CodeInvalidJSON is used when a JSON body can't be decoded
+	CodeInvalidJSON Code = "invalid_json"
+
+	// CodeItemCount is used when a list has too few or too many elements
+	CodeItemCount Code = "item_count"
+
+	// CodeMissing is used when the input of a Required Value is absent or null
+	CodeMissing Code = "missing_parameter"
+
+	// CodeRuleFailed is used when one of the Rules of the Value returns an error
+	CodeRuleFailed Code = "rule_failed"
+)
+
+// FieldError is the error returned when a single Value fails validation because of bad input.
+// A Value or struct that is misconfigured, which is a bug rather than bad input, returns one of
+// UnsupportedTypeError, InvalidDefaultError, InvalidBaseError, RuleTypeError or TagError
+// instead, or panics with it if the Validator is strict.
+type FieldError struct {
+	// Name is the Name of the Value that failed
+	Name string
+
+	// Input is the raw input that failed, after the Default has been applied
+	Input string
+
+	// Expected describes the type the input must be parsed into, such as "an int64".
+	// This is only set for a CodeTypeMismatch.
+	Expected string
+
+	// Code is the machine-readable reason for the failure
+	Code Code
+
+	// Err is the underlying error, such as the error returned from a Rule
+	Err error
+
+	// Message overrides the message returned by Error, and is set by the
+	// ErrorFormatter of a Validator
+	Message string
+}
+
+// Error returns a user friendly message describing the failure.
+func (err *FieldError) Error() string {
+	if err.Message != "" {
+		return err.Message
+	}
+	if err.Err != nil {
+		return err.Err.Error()
+	}
+	if err.Code == CodeMissing {
+		return fmt.Sprintf("Missing `%v` parameter", err.Name)
+	}
+	return fmt.Sprintf("Invalid `%v` parameter, `%v` must be %v", err.Name, err.Name, err.Expected)
+}
+
+// Unwrap returns the underlying error.
+func (err *FieldError) Unwrap() error {
+	return err.Err
+}
+
+// invalidParam builds the FieldError a TypeHandler returns when input can't be parsed
+func invalidParam(value *Value, input string, expected string) error {
+	return &FieldError{Name: value.Name, Input: input, Expected: expected, Code: CodeTypeMismatch}
+}
+
+// outOfRange builds the FieldError a TypeHandler returns when a number doesn't fit the Result
+func outOfRange(value *Value, input string, expected string) error {
+	return &FieldError{Name: value.Name, Input: input, Expected: expected, Code: CodeOutOfRange}
+}
+
+// ruleFailed wraps an error returned from a Rule in a FieldError
+func ruleFailed(value *Value, input string, err error) error {
+	var fieldErr *FieldError
+	if errors.As(err, &fieldErr) {
+		return err
+	}
+	return &FieldError{Name: value.Name, Input: input, Code: CodeRuleFailed, Err: err}
+}
+
+// ErrUnsupportedType matches, with errors.Is, every UnsupportedTypeError.
+var ErrUnsupportedType = errors.New("go-carrot/validator: unsupported Result type")
+
+// UnsupportedTypeError is returned when a Value without a TypeHandler has a Result
+// of a type that can't be handled by default, or by any registered TypeHandler.
+type UnsupportedTypeError struct {
+	// Name is the Name of the misconfigured Value
+	Name string
+
+	// Type is the type of the Result
+	Type reflect.Type
+}
+
+// Error describes the unsupported type.
+func (err *UnsupportedTypeError) Error() string {
+	return fmt.Sprintf("go-carrot/validator cannot by default handle a Value with Result of type %v.  Must set a custom TypeHandler for %v.", err.Type, err.Name)
+}
+
+// Is reports whether target is ErrUnsupportedType.
+func (err *UnsupportedTypeError) Is(target error) bool {
+	return target == ErrUnsupportedType
+}
+
+// ErrInvalidDefault matches, with errors.Is, every InvalidDefaultError.
+var ErrInvalidDefault = errors.New("go-carrot/validator: invalid default")
+
+// InvalidDefaultError is returned when the Default or DefaultValue of a Value doesn't fit
+// its Result.  Check finds this up front, and otherwise a Default is only parsed once an
+// input is absent.
+type InvalidDefaultError struct {
+	// Name is the Name of the misconfigured Value
+	Name string
+
+	// Err describes why the default doesn't fit.  It isn't unwrapped, so that a
+	// FieldError from parsing the Default isn't mistaken for bad input.
+	Err error
+}
+
+// Error describes the invalid default.
+func (err *InvalidDefaultError) Error() string {
+	return fmt.Sprintf("go-carrot/validator: invalid default for %v: %v", err.Name, err.Err)
+}
+
+// Is reports whether target is ErrInvalidDefault.
+func (err *InvalidDefaultError) Is(target error) bool {
+	return target == ErrInvalidDefault
+}
+
+// ErrInvalidBase matches, with errors.Is, every InvalidBaseError.
+var ErrInvalidBase = errors.New("go-carrot/validator: invalid base")
+
+// InvalidBaseError is returned when the Base a Value parses integers in, either its own or
+// that of its Validator, is neither BasePrefixed nor from 2 to 36.
+type InvalidBaseError struct {
+	// Name is the Name of the misconfigured Value
+	Name string
+
+	// Base is the invalid base
+	Base int
+}
+
+// Error describes the invalid base.
+func (err *InvalidBaseError) Error() string {
+	return fmt.Sprintf("go-carrot/validator: invalid base %v for %v, which must be BasePrefixed or from 2 to 36", err.Base, err.Name)
+}
+
+// Is reports whether target is ErrInvalidBase.
+func (err *InvalidBaseError) Is(target error) bool {
+	return target == ErrInvalidBase
+}
+
+// ErrRuleType matches, with errors.Is, every RuleTypeError.
+var ErrRuleType = errors.New("go-carrot/validator: post rule doesn't fit the Result")
+
+// RuleTypeError is returned when a PostRule built with Post is used on a Value whose Result
+// is of another type.  Post can't check T against the Result in advance, so this is only
+// found once an input is parsed and the PostRule runs.
+type RuleTypeError struct {
+	// Name is the Name of the misconfigured Value
+	Name string
+
+	// Type is the type of Result the PostRule expects
+	Type reflect.Type
+
+	// Result is the type of the Result
+	Result reflect.Type
+}
+
+// Error describes the mismatched types.
+func (err *RuleTypeError) Error() string {
+	return fmt.Sprintf("go-carrot/validator: post rule for %v expects a Result of type %v, not %v", err.Name, err.Type, err.Result)
+}
+
+// Is reports whether target is ErrRuleType.
+func (err *RuleTypeError) Is(target error) bool {
+	return target == ErrRuleType
+}
+
+// TagError is returned by ValidateStruct when a `validate` struct tag is malformed, before
+// any field is validated.
+type TagError struct {
+	// Field is the name of the struct field
+	Field string
+
+	// Tag is the malformed `validate` tag
+	Tag string
+
+	// Err describes what is wrong with the tag
+	Err error
+}
+
+// Error describes the malformed tag.
+func (err *TagError) Error() string {
+	return fmt.Sprintf("go-carrot/validator: invalid validate tag %q on field %v: %v", err.Tag, err.Field, err.Err)
+}
+
+// Unwrap returns the error describing what is wrong with the tag.
+func (err *TagError) Unwrap() error {
+	return err.Err
+}
+
+// Errors is an aggregate of the errors produced by ValidateAll, holding
+// one error per failing Value in the order the Values were passed in.
+type Errors []error
+
+// Error joins the message of every contained error.
+func (errs Errors) Error() string {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap exposes the contained errors to errors.Is and errors.As.
+func (errs Errors) Unwrap() []error {
+	return errs
+}
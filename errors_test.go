@@ -0,0 +1,62 @@
+package validator_test
+
+import (
+	"errors"
+	"testing"
+
+	v "github.com/go-carrot/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestErrors tests the message and unwrapping of an Errors aggregate
+func TestErrors(t *testing.T) {
+	first := errors.New("first")
+	second := errors.New("second")
+	errs := v.Errors{first, second}
+
+	assert.Equal(t, "first; second", errs.Error())
+	assert.True(t, errors.Is(errs, first))
+	assert.True(t, errors.Is(errs, second))
+	assert.False(t, errors.Is(errs, errors.New("first")))
+}
+
+// TestFieldErrorTypeMismatch tests the FieldError returned by a built-in type handler
+func TestFieldErrorTypeMismatch(t *testing.T) {
+	var id int64
+	err := v.Validate([]*v.Value{
+		{Result: &id, Name: "id", Input: "12a"},
+	})
+
+	var fieldErr *v.FieldError
+	assert.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, "id", fieldErr.Name)
+	assert.Equal(t, "12a", fieldErr.Input)
+	assert.Equal(t, "an int64", fieldErr.Expected)
+	assert.Equal(t, v.CodeTypeMismatch, fieldErr.Code)
+	assert.Equal(t, "Invalid `id` parameter, `id` must be an int64", err.Error())
+}
+
+// TestFieldErrorRuleFailed tests that rule errors are wrapped in a FieldError
+func TestFieldErrorRuleFailed(t *testing.T) {
+	var id int
+	err := v.Validate([]*v.Value{
+		{Result: &id, Name: "id", Input: "30", Rules: []v.Rule{MaxVal(20)}},
+	})
+
+	var fieldErr *v.FieldError
+	assert.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, "id", fieldErr.Name)
+	assert.Equal(t, "30", fieldErr.Input)
+	assert.Equal(t, v.CodeRuleFailed, fieldErr.Code)
+	assert.Equal(t, "The value of id may not be greater than 20", err.Error())
+	assert.NotNil(t, errors.Unwrap(err))
+
+	// Test that a FieldError returned from a rule isn't wrapped again
+	ruleErr := &v.FieldError{Name: "id", Code: "too_large", Err: errors.New("too large")}
+	err = v.Validate([]*v.Value{
+		{Result: &id, Name: "id", Input: "30", Rules: []v.Rule{func(name string, input string) error {
+			return ruleErr
+		}}},
+	})
+	assert.Equal(t, ruleErr, err)
+}
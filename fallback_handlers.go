@@ -0,0 +1,113 @@
+package validator
+
+import (
+	"database/sql"
+	"encoding"
+	"fmt"
+	"reflect"
+)
+
+// fallbackTypeHandler returns a TypeHandler for a Result without a built-in handler.  Results
+// implementing encoding.TextUnmarshaler or sql.Scanner are handled through that interface, and
+// named types of a supported kind, such as `type UserID int64`, through reflection.  nil is
+// returned if there is no handler.
+func fallbackTypeHandler(result interface{}) TypeHandler {
+	switch result.(type) {
+	case encoding.TextUnmarshaler:
+		return textUnmarshalerHandler
+	case sql.Scanner:
+		return scannerHandler
+	}
+	t := reflect.TypeOf(result)
+	if t == nil || t.Kind() != reflect.Ptr {
+		return nil
+	}
+	switch t.Elem().Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return kindHandler
+	}
+	return nil
+}
+
+// textUnmarshalerHandler parses input with the UnmarshalText method of the Result.  It decodes
+// into a new value, as UnmarshalText may leave its receiver half written when it fails.
+func textUnmarshalerHandler(input string, value *Value) error {
+	if skipEmpty(input, value) {
+		return nil
+	}
+	result := reflect.ValueOf(value.Result)
+	res := reflect.New(result.Type().Elem())
+	err := res.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(input))
+	if err != nil {
+		return invalidParam(value, input, fmt.Sprintf("a valid %v", result.Type().Elem()))
+	}
+	result.Elem().Set(res.Elem())
+	return nil
+}
+
+// scannerHandler parses input with the Scan method of the Result, scanning empty input as
+// nil so that types like sql.NullString become invalid.  It scans into a new value, and
+// replaces the Result with it on success.
+func scannerHandler(input string, value *Value) error {
+	result := reflect.ValueOf(value.Result)
+	res := reflect.New(result.Type().Elem())
+	var src interface{}
+	if input != "" {
+		src = input
+	}
+	err := res.Interface().(sql.Scanner).Scan(src)
+	if err != nil {
+		return invalidParam(value, input, fmt.Sprintf("a valid %v", result.Type().Elem()))
+	}
+	result.Elem().Set(res.Elem())
+	return nil
+}
+
+// kindHandler parses input into a Result of a named type, based on its underlying kind
+func kindHandler(input string, value *Value) error {
+	result := reflect.ValueOf(value.Result).Elem()
+	kind := result.Kind()
+	if kind == reflect.Bool && value.Checkbox {
+		result.SetBool(value.Presence() == InputPresent)
+		return nil
+	}
+	if kind != reflect.String && skipEmpty(input, value) {
+		return nil
+	}
+	switch kind {
+	case reflect.String:
+		err := checkJSONKind(input, value, JSONString, "a string")
+		if err != nil {
+			return err
+		}
+		result.SetString(input)
+	case reflect.Bool:
+		res, err := parseBool(input, value)
+		if err != nil {
+			return err
+		}
+		result.SetBool(res)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		res, err := parseInt(input, value, result.Type().Bits(), "an "+kind.String())
+		if err != nil {
+			return err
+		}
+		result.SetInt(res)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		res, err := parseUint(input, value, result.Type().Bits(), "a "+kind.String())
+		if err != nil {
+			return err
+		}
+		result.SetUint(res)
+	case reflect.Float32, reflect.Float64:
+		res, err := parseFloat(input, value, result.Type().Bits(), "a "+kind.String())
+		if err != nil {
+			return err
+		}
+		result.SetFloat(res)
+	}
+	return nil
+}
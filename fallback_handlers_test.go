@@ -0,0 +1,109 @@
+package validator_test
+
+import (
+	"database/sql"
+	"net"
+	"testing"
+
+	v "github.com/go-carrot/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+// UserID, Status, Ratio and Enabled are named types used to test the reflection fallback
+type (
+	UserID  int64
+	Status  string
+	Ratio   float32
+	Enabled bool
+	Port    uint16
+)
+
+// TestNamedTypes tests handling of named types of supported kinds as the result
+func TestNamedTypes(t *testing.T) {
+	// Test success case
+	var id UserID
+	var status Status
+	var ratio Ratio
+	var enabled Enabled
+	var port Port
+	var ids []UserID
+	err := v.Validate([]*v.Value{
+		{Result: &id, Name: "id", Input: "42"},
+		{Result: &status, Name: "status", Input: "active"},
+		{Result: &ratio, Name: "ratio", Input: "0.5"},
+		{Result: &enabled, Name: "enabled", Input: "true"},
+		{Result: &port, Name: "port", Input: "8080"},
+		{Result: &ids, Name: "id", Inputs: []string{"1", "2"}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, UserID(42), id)
+	assert.Equal(t, Status("active"), status)
+	assert.Equal(t, Ratio(0.5), ratio)
+	assert.Equal(t, Enabled(true), enabled)
+	assert.Equal(t, Port(8080), port)
+	assert.Equal(t, []UserID{1, 2}, ids)
+
+	// Test failure cases
+	var failureId UserID
+	err = v.Validate([]*v.Value{
+		{Result: &failureId, Name: "id", Input: "abc"},
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, "Invalid `id` parameter, `id` must be an int64", err.Error())
+	assert.Equal(t, UserID(0), failureId)
+
+	var failurePort Port
+	err = v.Validate([]*v.Value{
+		{Result: &failurePort, Name: "port", Input: "70000"},
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, "Invalid `port` parameter, `port` must be between 0 and 65535", err.Error())
+}
+
+// TestTextUnmarshaler tests handling of an encoding.TextUnmarshaler as the result
+func TestTextUnmarshaler(t *testing.T) {
+	// Test success case
+	var ip net.IP
+	err := v.Validate([]*v.Value{
+		{Result: &ip, Name: "ip", Input: "192.168.0.1"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "192.168.0.1", ip.String())
+
+	// Test failure case
+	failureIp := net.ParseIP("10.0.0.1")
+	err = v.Validate([]*v.Value{
+		{Result: &failureIp, Name: "ip", Input: "abc"},
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, "Invalid `ip` parameter, `ip` must be a valid net.IP", err.Error())
+	assert.Equal(t, "10.0.0.1", failureIp.String())
+}
+
+// TestScanner tests handling of a sql.Scanner as the result
+func TestScanner(t *testing.T) {
+	// Test success case
+	var id sql.NullInt64
+	err := v.Validate([]*v.Value{
+		{Result: &id, Name: "id", Input: "42"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, sql.NullInt64{Int64: 42, Valid: true}, id)
+
+	// Test empty case
+	emptyId := sql.NullInt64{Int64: 42, Valid: true}
+	err = v.Validate([]*v.Value{
+		{Result: &emptyId, Name: "id", Input: ""},
+	})
+	assert.Nil(t, err)
+	assert.False(t, emptyId.Valid)
+
+	// Test failure case
+	var failureId sql.NullInt64
+	err = v.Validate([]*v.Value{
+		{Result: &failureId, Name: "id", Input: "abc"},
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, "Invalid `id` parameter, `id` must be a valid sql.NullInt64", err.Error())
+	assert.False(t, failureId.Valid)
+}
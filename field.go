@@ -0,0 +1,73 @@
+package validator
+
+import (
+	"errors"
+)
+
+// Field is a type-safe alternative to Value.  Dst, Parse and PostRules all share T, so a
+// mismatch between them is caught at compile time rather than inside a TypeHandler.
+//
+// A Field is validated by passing the Value it builds to Validate:
+//
+//	err := Validate([]*Value{
+//	    Field[UserID]{Dst: &id, Name: "id", Input: "100", Parse: ParseUserID}.Value(),
+//	})
+type Field[T any] struct {
+	// Dst points to the variable the parsed input is stored in
+	Dst *T
+
+	Name     string
+	Input    string
+	Default  string
+	Required bool
+	Optional bool
+	Rules    []Rule
+
+	// PostRules run against the parsed value.  See PostRule.
+	PostRules []func(name string, result T) error
+
+	// Parse parses the input into a T.  If Parse is nil, the TypeHandler for *T is used,
+	// as it would be for a Value with Dst as its Result.
+	Parse func(input string) (T, error)
+}
+
+// Value builds the Value the Field compiles down to.
+func (field Field[T]) Value() *Value {
+	value := &Value{
+		Result:   field.Dst,
+		Name:     field.Name,
+		Input:    field.Input,
+		Default:  field.Default,
+		Required: field.Required,
+		Optional: field.Optional,
+		Rules:    field.Rules,
+	}
+	for _, rule := range field.PostRules {
+		value.PostRules = append(value.PostRules, Post(rule))
+	}
+	if field.Parse != nil {
+		value.TypeHandler = parseHandler(field.Parse)
+	}
+	return value
+}
+
+// parseHandler builds a TypeHandler from the Parse function of a Field.  Like the built-in
+// handlers, it skips empty input for an Optional Field, and an error returned by Parse is
+// wrapped in a FieldError unless it is one already.
+func parseHandler[T any](parse func(input string) (T, error)) TypeHandler {
+	return func(input string, value *Value) error {
+		if skipEmpty(input, value) {
+			return nil
+		}
+		parsed, err := parse(input)
+		if err != nil {
+			var fieldErr *FieldError
+			if errors.As(err, &fieldErr) {
+				return err
+			}
+			return &FieldError{Name: value.Name, Input: input, Code: CodeTypeMismatch, Err: err}
+		}
+		*value.Result.(*T) = parsed
+		return nil
+	}
+}
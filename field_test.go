@@ -0,0 +1,98 @@
+package validator_test
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	v "github.com/go-carrot/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+// AccountID is an ID parsed from an "account-" prefixed input
+type AccountID int
+
+// ParseAccountID parses an "account-" prefixed input into an AccountID
+func ParseAccountID(input string) (AccountID, error) {
+	id, err := strconv.Atoi(strings.TrimPrefix(input, "account-"))
+	if err != nil || !strings.HasPrefix(input, "account-") {
+		return 0, fmt.Errorf("`%v` is not an account ID", input)
+	}
+	return AccountID(id), nil
+}
+
+// TestField tests the Value built by a Field
+func TestField(t *testing.T) {
+	// Test success case
+	var id AccountID
+	var limit int
+	var name string
+	err := v.Validate([]*v.Value{
+		v.Field[AccountID]{Dst: &id, Name: "id", Input: "account-42", Parse: ParseAccountID}.Value(),
+		v.Field[int]{Dst: &limit, Name: "limit", Default: "10", PostRules: []func(string, int) error{
+			func(name string, result int) error {
+				if result > 100 {
+					return fmt.Errorf("%v is too large", name)
+				}
+				return nil
+			},
+		}}.Value(),
+		v.Field[string]{Dst: &name, Name: "name", Input: "Brandon", Rules: []v.Rule{IsSet}}.Value(),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, AccountID(42), id)
+	assert.Equal(t, 10, limit)
+	assert.Equal(t, "Brandon", name)
+
+	// Test parse failure case
+	err = v.Validate([]*v.Value{
+		v.Field[AccountID]{Dst: &id, Name: "id", Input: "42", Parse: ParseAccountID}.Value(),
+	})
+	var fieldErr *v.FieldError
+	assert.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, v.CodeTypeMismatch, fieldErr.Code)
+	assert.Equal(t, "id", fieldErr.Name)
+	assert.Equal(t, "42", fieldErr.Input)
+	assert.Equal(t, "`42` is not an account ID", err.Error())
+
+	// Test post rule failure case
+	err = v.Validate([]*v.Value{
+		v.Field[int]{Dst: &limit, Name: "limit", Input: "200", PostRules: []func(string, int) error{
+			func(name string, result int) error {
+				return fmt.Errorf("%v is too large", name)
+			},
+		}}.Value(),
+	})
+	assert.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, v.CodeRuleFailed, fieldErr.Code)
+
+	// Test required case
+	err = v.Validate([]*v.Value{
+		v.Field[int]{Dst: &limit, Name: "limit", Required: true}.Value(),
+	})
+	assert.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, v.CodeMissing, fieldErr.Code)
+
+	// Test optional case
+	err = v.Validate([]*v.Value{
+		v.Field[AccountID]{Dst: &id, Name: "id", Optional: true, Parse: ParseAccountID}.Value(),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, AccountID(42), id)
+
+	// Test optional present but empty case, where Parse isn't called
+	calls := 0
+	countingParse := func(input string) (AccountID, error) {
+		calls++
+		return ParseAccountID(input)
+	}
+	emptyValue := v.Field[AccountID]{Dst: &id, Name: "id", Optional: true, Parse: countingParse}.Value()
+	emptyValue.State = v.InputPresent
+	err = v.Validate([]*v.Value{emptyValue})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, calls)
+	assert.Equal(t, AccountID(42), id)
+	assert.False(t, emptyValue.Supplied())
+}
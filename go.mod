@@ -0,0 +1,14 @@
+module github.com/go-carrot/validator
+
+go 1.22
+
+require (
+	github.com/stretchr/testify v1.8.4
+	gopkg.in/guregu/null.v3 v3.5.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
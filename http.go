@@ -0,0 +1,128 @@
+package validator
+
+import (
+	"mime"
+	"net/http"
+)
+
+// Source is where in an *http.Request FromRequest finds the inputs of a Value.
+type Source int
+
+const (
+	// SourceNone leaves the Input of the Value as it is
+	SourceNone Source = iota
+
+	// SourceQuery reads inputs from the URL query string
+	SourceQuery
+
+	// SourceForm reads inputs from a URL encoded or multipart form body
+	SourceForm
+
+	// SourceHeader reads inputs from the request headers
+	SourceHeader
+
+	// SourceCookie reads inputs from the request cookies
+	SourceCookie
+
+	// SourcePath reads the input from a wildcard of the matched http.ServeMux pattern
+	SourcePath
+)
+
+// maxMemory is the number of bytes of a multipart form body held in memory
+const maxMemory = 32 << 20
+
+// FromRequest fills in the inputs of each Value from r, and then validates them using
+// the default Validator.  See (*Validator).FromRequest.
+func FromRequest(r *http.Request, values []*Value) error {
+	return defaultValidator.FromRequest(r, values)
+}
+
+// FromRequest fills in the inputs of each Value from the part of r named by its Source,
+// using the Name of the Value as the key, and then validates them with the context of r.
+// Values with SourceNone are left as they are.  Multipart forms are parsed as well as URL
+// encoded ones.
+func (v *Validator) FromRequest(r *http.Request, values []*Value) error {
+	for _, value := range values {
+		if value.Source == SourceNone {
+			continue
+		}
+		inputs, err := requestInputs(r, value.Source, value.Name)
+		if err != nil {
+			return err
+		}
+		setInputs(value, inputs)
+	}
+	return v.ValidateContext(r.Context(), values)
+}
+
+// RequestSource creates a source for ValidateStruct that reads inputs from the part of r
+// named by source.  A form body is parsed up front, so that an error parsing it is returned
+// here rather than looking like every field is absent.
+func RequestSource(r *http.Request, source Source) (func(name string) []string, error) {
+	if source == SourceForm {
+		err := parseForm(r)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return func(name string) []string {
+		inputs, _ := requestInputs(r, source, name)
+		return inputs
+	}, nil
+}
+
+// requestInputs returns the inputs for the key name from the part of r named by source
+func requestInputs(r *http.Request, source Source, name string) ([]string, error) {
+	switch source {
+	case SourceQuery:
+		return r.URL.Query()[name], nil
+	case SourceForm:
+		err := parseForm(r)
+		if err != nil {
+			return nil, err
+		}
+		return r.PostForm[name], nil
+	case SourceHeader:
+		return r.Header.Values(name), nil
+	case SourceCookie:
+		var inputs []string
+		for _, cookie := range r.Cookies() {
+			if cookie.Name == name {
+				inputs = append(inputs, cookie.Value)
+			}
+		}
+		return inputs, nil
+	case SourcePath:
+		if input := r.PathValue(name); input != "" {
+			return []string{input}, nil
+		}
+	}
+	return nil, nil
+}
+
+// parseForm parses the body of r, as a multipart form if it is one
+func parseForm(r *http.Request) error {
+	if r.PostForm != nil {
+		return nil
+	}
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "multipart/form-data" {
+		return r.ParseMultipartForm(maxMemory)
+	}
+	return r.ParseForm()
+}
+
+// setInputs sets inputs on a Value, as its Inputs if the Result is a slice,
+// and otherwise as its Input.  nil inputs mean the input is absent.
+func setInputs(value *Value, inputs []string) {
+	value.JSONKind = JSONNone
+	value.State = InputPresent
+	if inputs == nil {
+		value.State = InputAbsent
+	}
+	if isSliceResult(value.Result) {
+		value.Inputs = inputs
+	} else if len(inputs) > 0 {
+		value.Input = inputs[0]
+	}
+}
@@ -0,0 +1,142 @@
+package validator_test
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	v "github.com/go-carrot/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestFromRequestQuery tests reading inputs from the query string
+func TestFromRequestQuery(t *testing.T) {
+	r := httptest.NewRequest("GET", "/users?id=12&tag=a&tag=b", nil)
+
+	// Test success case
+	var id int
+	var tags []string
+	var other string
+	err := v.FromRequest(r, []*v.Value{
+		{Result: &id, Name: "id", Source: v.SourceQuery},
+		{Result: &tags, Name: "tag", Source: v.SourceQuery},
+		{Result: &other, Name: "other", Input: "unchanged"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 12, id)
+	assert.Equal(t, []string{"a", "b"}, tags)
+	assert.Equal(t, "unchanged", other)
+
+	// Test failure case
+	var missing string
+	err = v.FromRequest(r, []*v.Value{
+		{Result: &missing, Name: "missing", Source: v.SourceQuery, Rules: []v.Rule{IsSet}},
+	})
+	assert.NotNil(t, err)
+}
+
+// TestFromRequestForm tests reading inputs from a URL encoded form body
+func TestFromRequestForm(t *testing.T) {
+	form := url.Values{"name": {"Brandon"}, "age": {"30"}}
+	r := httptest.NewRequest("POST", "/users?name=ignored", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var name string
+	var age int
+	err := v.FromRequest(r, []*v.Value{
+		{Result: &name, Name: "name", Source: v.SourceForm},
+		{Result: &age, Name: "age", Source: v.SourceForm},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "Brandon", name)
+	assert.Equal(t, 30, age)
+}
+
+// TestFromRequestMultipart tests reading inputs from a multipart form body
+func TestFromRequestMultipart(t *testing.T) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	writer.WriteField("name", "Brandon")
+	writer.WriteField("id", "1")
+	writer.WriteField("id", "2")
+	writer.Close()
+	r := httptest.NewRequest("POST", "/users", body)
+	r.Header.Set("Content-Type", writer.FormDataContentType())
+
+	var name string
+	var ids []int
+	err := v.FromRequest(r, []*v.Value{
+		{Result: &name, Name: "name", Source: v.SourceForm},
+		{Result: &ids, Name: "id", Source: v.SourceForm},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "Brandon", name)
+	assert.Equal(t, []int{1, 2}, ids)
+}
+
+// TestFromRequestHeaderCookiePath tests reading inputs from headers, cookies and the path
+func TestFromRequestHeaderCookiePath(t *testing.T) {
+	var token string
+	var session string
+	var id int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		err := v.FromRequest(r, []*v.Value{
+			{Result: &token, Name: "X-Token", Source: v.SourceHeader},
+			{Result: &session, Name: "session", Source: v.SourceCookie},
+			{Result: &id, Name: "id", Source: v.SourcePath},
+		})
+		assert.Nil(t, err)
+	})
+
+	r := httptest.NewRequest("GET", "/users/42", nil)
+	r.Header.Set("X-Token", "secret")
+	r.AddCookie(&http.Cookie{Name: "session", Value: "abc"})
+	mux.ServeHTTP(httptest.NewRecorder(), r)
+	assert.Equal(t, "secret", token)
+	assert.Equal(t, "abc", session)
+	assert.Equal(t, 42, id)
+}
+
+// TestRequestSource tests using a request as the source of ValidateStruct
+func TestRequestSource(t *testing.T) {
+	r := httptest.NewRequest("GET", "/users?user_id=12&tag=a&tag=b", nil)
+
+	var request struct {
+		UserID int      `validate:"name=user_id,rules=required"`
+		Tags   []string `validate:"name=tag"`
+	}
+	source, err := v.RequestSource(r, v.SourceQuery)
+	assert.Nil(t, err)
+	err = v.ValidateStruct(&request, source)
+	assert.Nil(t, err)
+	assert.Equal(t, 12, request.UserID)
+	assert.Equal(t, []string{"a", "b"}, request.Tags)
+}
+
+// TestMalformedForm tests that a form body that can't be parsed is an error, rather than
+// every field being absent
+func TestMalformedForm(t *testing.T) {
+	newRequest := func() *http.Request {
+		r := httptest.NewRequest("POST", "/users", strings.NewReader("name=%zz"))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return r
+	}
+
+	// Test FromRequest case
+	name := "unchanged"
+	err := v.FromRequest(newRequest(), []*v.Value{
+		{Result: &name, Name: "name", Source: v.SourceForm, Optional: true},
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, "unchanged", name)
+
+	// Test RequestSource case
+	source, err := v.RequestSource(newRequest(), v.SourceForm)
+	assert.NotNil(t, err)
+	assert.Nil(t, source)
+}
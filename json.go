@@ -0,0 +1,230 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// InputState describes whether the input of a Value was supplied.
+type InputState int
+
+const (
+	// InputUnset means the state wasn't recorded, and only the Input is known
+	InputUnset InputState = iota
+
+	// InputAbsent means the input wasn't supplied at all, such as a missing JSON key
+	InputAbsent
+
+	// InputNull means the input was explicitly supplied as null
+	InputNull
+
+	// InputPresent means the input was supplied, even if it is an empty string
+	InputPresent
+)
+
+// JSONKind is the kind of JSON an input was decoded from, which the built-in TypeHandlers
+// check so that a quoted number isn't accepted as a number, nor a bare number as a string.
+type JSONKind int
+
+const (
+	// JSONNone means the input wasn't decoded from JSON, or was null
+	JSONNone JSONKind = iota
+
+	// JSONString means the input was a JSON string
+	JSONString
+
+	// JSONNumber means the input was a JSON number
+	JSONNumber
+
+	// JSONBool means the input was true or false
+	JSONBool
+
+	// JSONObject means the input was a JSON object
+	JSONObject
+
+	// JSONArray means the input was a JSON array.  The Inputs of a slice Result take the
+	// kind of the elements instead, unless they are of different kinds.
+	JSONArray
+)
+
+// String returns the name of the kind, such as "string".
+func (kind JSONKind) String() string {
+	switch kind {
+	case JSONString:
+		return "string"
+	case JSONNumber:
+		return "number"
+	case JSONBool:
+		return "bool"
+	case JSONObject:
+		return "object"
+	case JSONArray:
+		return "array"
+	}
+	return "none"
+}
+
+// ValidateJSON decodes a JSON document from body, fills in the inputs of each Value from it,
+// and then validates them using the default Validator.  See (*Validator).ValidateJSON.
+func ValidateJSON(body io.Reader, values []*Value) error {
+	return defaultValidator.ValidateJSON(body, values)
+}
+
+// ValidateJSON decodes a JSON document from body, fills in the inputs of each Value from it,
+// and then validates them.
+//
+// Each Value is found in the document by its Pointer, a JSON pointer such as "/user/id",
+// which defaults to "/" followed by its Name.  Its State is set to InputAbsent, InputNull or
+// InputPresent, its JSONKind to the kind of JSON found there, and its Raw to that JSON.  Strings, numbers and booleans become the
+// Input of the Value, and arrays its Inputs if its Result is a slice.  Objects, and arrays for
+// other Results, become the Input as JSON text.
+func (v *Validator) ValidateJSON(body io.Reader, values []*Value) error {
+	// Decode the document
+	var document interface{}
+	decoder := json.NewDecoder(body)
+	decoder.UseNumber()
+	err := decoder.Decode(&document)
+	if err != nil {
+		return v.format(&FieldError{Name: "body", Code: CodeInvalidJSON, Err: err})
+	}
+
+	// Fill in the inputs of each Value
+	for _, value := range values {
+		pointer := value.Pointer
+		if pointer == "" {
+			pointer = "/" + escapePointer(value.Name)
+		}
+		found, ok := resolvePointer(document, pointer)
+		setJSONInputs(value, found, ok)
+	}
+	return v.Validate(values)
+}
+
+// setJSONInputs sets the inputs of a Value from the JSON found at its pointer
+func setJSONInputs(value *Value, found interface{}, ok bool) {
+	value.Input = ""
+	value.Inputs = nil
+	value.Raw = nil
+	value.JSONKind = JSONNone
+	if !ok {
+		value.State = InputAbsent
+		return
+	}
+	value.Raw, _ = json.Marshal(found)
+	if found == nil {
+		value.State = InputNull
+		return
+	}
+	value.State = InputPresent
+	value.JSONKind = jsonKind(found)
+	if elems, isArray := found.([]interface{}); isArray && isListResult(value.Result) {
+		value.Inputs = make([]string, len(elems))
+		value.JSONKind = JSONNone
+		for i, elem := range elems {
+			value.Inputs[i] = jsonInput(elem)
+
+			// Taking the kind of the elements, ignoring nulls, or JSONArray if they differ
+			kind := jsonKind(elem)
+			if value.JSONKind == JSONNone {
+				value.JSONKind = kind
+			} else if kind != JSONNone && kind != value.JSONKind {
+				value.JSONKind = JSONArray
+			}
+		}
+		return
+	}
+	value.Input = jsonInput(found)
+}
+
+// jsonKind returns the kind of a decoded JSON value
+func jsonKind(found interface{}) JSONKind {
+	switch found.(type) {
+	case string:
+		return JSONString
+	case json.Number:
+		return JSONNumber
+	case bool:
+		return JSONBool
+	case map[string]interface{}:
+		return JSONObject
+	case []interface{}:
+		return JSONArray
+	}
+	return JSONNone
+}
+
+// checkJSONKind returns a FieldError if the input of a Value was decoded from JSON of another
+// kind than want, such as a quoted number for an int
+func checkJSONKind(input string, value *Value, want JSONKind, expected string) error {
+	if value.JSONKind == JSONNone || value.JSONKind == want {
+		return nil
+	}
+	return invalidParam(value, input, fmt.Sprintf("%v, not a JSON %v", expected, value.JSONKind))
+}
+
+// isListResult checks if a Result is a slice, or a pointer to one, which takes a JSON array as
+// its Inputs.  Any other Result gets the array as JSON text, which its TypeHandler rejects
+// unless it parses JSON.
+func isListResult(result interface{}) bool {
+	t := reflect.TypeOf(result)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t != nil && t.Kind() == reflect.Slice
+}
+
+// jsonInput converts a decoded JSON value into a string input
+func jsonInput(found interface{}) string {
+	switch found := found.(type) {
+	case nil:
+		return ""
+	case string:
+		return found
+	case json.Number:
+		return found.String()
+	case bool:
+		return strconv.FormatBool(found)
+	}
+	raw, _ := json.Marshal(found)
+	return string(raw)
+}
+
+// resolvePointer finds the value a JSON pointer (RFC 6901) refers to in a decoded document
+func resolvePointer(document interface{}, pointer string) (interface{}, bool) {
+	if pointer == "" {
+		return document, true
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, false
+	}
+	current := document
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		switch node := current.(type) {
+		case map[string]interface{}:
+			next, ok := node[token]
+			if !ok {
+				return nil, false
+			}
+			current = next
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, false
+			}
+			current = node[i]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// escapePointer escapes a name for use as a JSON pointer token
+func escapePointer(name string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
@@ -0,0 +1,150 @@
+package validator_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	v "github.com/go-carrot/validator"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/guregu/null.v3"
+)
+
+// TestValidateJSON tests filling in inputs from a JSON body
+func TestValidateJSON(t *testing.T) {
+	body := `{"id": 12, "name": "Brandon", "admin": true, "price": 1.5, "tags": ["a", "b"], "user": {"a/b": 3}}`
+
+	// Test success case
+	var id int
+	var name string
+	var admin bool
+	var price float64
+	var tags []string
+	var nested int
+	user := &v.Value{Result: new(string), Name: "user", TypeHandler: func(input string, value *v.Value) error {
+		*value.Result.(*string) = input
+		return nil
+	}}
+	err := v.ValidateJSON(strings.NewReader(body), []*v.Value{
+		{Result: &id, Name: "id"},
+		{Result: &name, Name: "name"},
+		{Result: &admin, Name: "admin"},
+		{Result: &price, Name: "price"},
+		{Result: &tags, Name: "tags"},
+		{Result: &nested, Name: "nested", Pointer: "/user/a~1b"},
+		user,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 12, id)
+	assert.Equal(t, "Brandon", name)
+	assert.True(t, admin)
+	assert.Equal(t, 1.5, price)
+	assert.Equal(t, []string{"a", "b"}, tags)
+	assert.Equal(t, 3, nested)
+	assert.Equal(t, `{"a/b":3}`, *user.Result.(*string))
+	assert.Equal(t, `{"a/b":3}`, string(user.Raw))
+	assert.Equal(t, v.InputPresent, user.State)
+	assert.Equal(t, v.JSONObject, user.JSONKind)
+
+	// Test failure case
+	var failureId int
+	err = v.ValidateJSON(strings.NewReader(`{"id": 1.5}`), []*v.Value{
+		{Result: &failureId, Name: "id"},
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, "Invalid `id` parameter, `id` must be an int", err.Error())
+
+	// Test array for a scalar case, which isn't truncated to its first element
+	err = v.ValidateJSON(strings.NewReader(`{"id": [1, 2, 3]}`), []*v.Value{
+		{Result: &failureId, Name: "id"},
+	})
+	var mismatchErr *v.FieldError
+	assert.True(t, errors.As(err, &mismatchErr))
+	assert.Equal(t, v.CodeTypeMismatch, mismatchErr.Code)
+	assert.Equal(t, "[1,2,3]", mismatchErr.Input)
+	assert.Equal(t, 0, failureId)
+
+	// Test array for a pointer to a slice case
+	var ids *[]int
+	err = v.ValidateJSON(strings.NewReader(`{"id": [1, 2, 3]}`), []*v.Value{
+		{Result: &ids, Name: "id"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 2, 3}, *ids)
+
+	// Test mismatched kind cases, where JSON isn't turned into another type through a string
+	kindCases := []struct {
+		result   interface{}
+		body     string
+		expected string
+	}{
+		{new(int), `{"n": "42"}`, "an int, not a JSON string"},
+		{new(float64), `{"n": "1.5"}`, "a float64, not a JSON string"},
+		{new(bool), `{"n": "true"}`, "a bool, not a JSON string"},
+		{new(string), `{"n": 123}`, "a string, not a JSON number"},
+		{new(string), `{"n": false}`, "a string, not a JSON bool"},
+		{new(string), `{"n": {"a": 1}}`, "a string, not a JSON object"},
+		{new(null.Int), `{"n": "42"}`, "an int64, not a JSON string"},
+		{new(null.String), `{"n": 123}`, "a string, not a JSON number"},
+		{new([]int), `{"n": [1, "2"]}`, "an int, not a JSON array"},
+		{new([]int), `{"n": ["1", "2"]}`, "an int, not a JSON string"},
+		{new([]string), `{"n": ["a", 2]}`, "a string, not a JSON array"},
+	}
+	for _, c := range kindCases {
+		err = v.ValidateJSON(strings.NewReader(c.body), []*v.Value{
+			{Result: c.result, Name: "n"},
+		})
+		var kindErr *v.FieldError
+		assert.True(t, errors.As(err, &kindErr), c.body)
+		assert.Equal(t, v.CodeTypeMismatch, kindErr.Code, c.body)
+		assert.Equal(t, c.expected, kindErr.Expected, c.body)
+	}
+
+	// Test matching kind cases, including nulls in arrays and strings in another base
+	var nullIds []null.Int
+	var hexId int
+	err = v.ValidateJSON(strings.NewReader(`{"ids": [1, null], "hex": "0xff"}`), []*v.Value{
+		{Result: &nullIds, Name: "ids"},
+		{Result: &hexId, Name: "hex", Base: v.BasePrefixed},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []null.Int{null.IntFrom(1), {}}, nullIds)
+	assert.Equal(t, 255, hexId)
+
+	// Test invalid body case
+	err = v.ValidateJSON(strings.NewReader(`{"id": `), []*v.Value{
+		{Result: &failureId, Name: "id"},
+	})
+	var fieldErr *v.FieldError
+	assert.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, v.CodeInvalidJSON, fieldErr.Code)
+}
+
+// TestValidateJSONStates tests that absent, null and present keys are told apart
+func TestValidateJSONStates(t *testing.T) {
+	body := `{"null_name": null, "empty_name": "", "name": "Brandon", "null_count": null}`
+
+	absent := &v.Value{Result: &null.String{}, Name: "absent_name"}
+	absentDefault := &v.Value{Result: &null.String{}, Name: "absent_default", Default: "default"}
+	nullName := &v.Value{Result: &null.String{}, Name: "null_name", Default: "default"}
+	emptyName := &v.Value{Result: &null.String{}, Name: "empty_name"}
+	name := &v.Value{Result: &null.String{}, Name: "name"}
+	nullCount := &v.Value{Result: &null.Int{}, Name: "null_count"}
+	err := v.ValidateJSON(strings.NewReader(body), []*v.Value{absent, absentDefault, nullName, emptyName, name, nullCount})
+	assert.Nil(t, err)
+
+	// Test absent
+	assert.Equal(t, v.InputAbsent, absent.State)
+	assert.False(t, absent.Result.(*null.String).Valid)
+	assert.Equal(t, null.StringFrom("default"), *absentDefault.Result.(*null.String))
+
+	// Test null, which doesn't use the default
+	assert.Equal(t, v.InputNull, nullName.State)
+	assert.False(t, nullName.Result.(*null.String).Valid)
+	assert.False(t, nullCount.Result.(*null.Int).Valid)
+
+	// Test present
+	assert.Equal(t, v.InputPresent, emptyName.State)
+	assert.Equal(t, null.StringFrom(""), *emptyName.Result.(*null.String))
+	assert.Equal(t, null.StringFrom("Brandon"), *name.Result.(*null.String))
+}
@@ -1,10 +1,6 @@
 package validator
 
 import (
-	"errors"
-	"strconv"
-	"time"
-
 	"gopkg.in/guregu/null.v3"
 )
 
@@ -19,9 +15,9 @@ func nullIntHandler(input string, value *Value) error {
 	}
 
 	// Get int64
-	res, err := strconv.ParseInt(input, 10, 64)
+	res, err := parseInt(input, value, 64, "an int64")
 	if err != nil {
-		return errors.New(invalidParam(value.Name, "an int64"))
+		return err
 	}
 
 	// Update null.Int
@@ -31,9 +27,13 @@ func nullIntHandler(input string, value *Value) error {
 }
 
 func nullStringHandler(input string, value *Value) error {
+	err := checkJSONKind(input, value, JSONString, "a string")
+	if err != nil {
+		return err
+	}
 	nullString := value.Result.(*null.String)
 	(*nullString).String = input
-	(*nullString).Valid = len(input) != 0
+	(*nullString).Valid = len(input) != 0 || value.Presence() == InputPresent
 	return nil
 }
 
@@ -48,9 +48,9 @@ func nullFloatHandler(input string, value *Value) error {
 	}
 
 	// Get float64
-	res, err := strconv.ParseFloat(input, 64)
+	res, err := parseFloat(input, value, 64, "a float64")
 	if err != nil {
-		return errors.New(invalidParam(value.Name, "a float64"))
+		return err
 	}
 
 	// Update null.Float
@@ -63,6 +63,13 @@ func nullBoolHandler(input string, value *Value) error {
 	// Cast
 	nullBool := value.Result.(*null.Bool)
 
+	// Check for a checkbox, which is true when present
+	if value.Checkbox {
+		(*nullBool).Bool = value.Presence() == InputPresent
+		(*nullBool).Valid = true
+		return nil
+	}
+
 	// Check for empty
 	if len(input) == 0 {
 		(*nullBool).Valid = false
@@ -70,9 +77,9 @@ func nullBoolHandler(input string, value *Value) error {
 	}
 
 	// Get bool
-	res, err := strconv.ParseBool(input)
+	res, err := parseBool(input, value)
 	if err != nil {
-		return errors.New(invalidParam(value.Name, "a bool"))
+		return err
 	}
 
 	// Update null.Bool
@@ -92,9 +99,9 @@ func nullTimeHandler(input string, value *Value) error {
 	}
 
 	// Get time.Time
-	res, err := time.Parse(time.RFC3339, input)
+	res, err := parseTime(input, value)
 	if err != nil {
-		return errors.New(invalidParam(value.Name, "an RFC 3339 date-time (2006-01-02T15:04:05Z07:00)"))
+		return err
 	}
 
 	// Update null.Time
@@ -1,6 +1,7 @@
 package validator_test
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -34,6 +35,24 @@ func TestNullInt(t *testing.T) {
 	})
 	assert.NotNil(t, err)
 	assert.False(t, failureId.Valid)
+
+	// Test base case
+	var hexId null.Int
+	err = v.Validate([]*v.Value{
+		{Result: &hexId, Name: "id", Input: "ff", Base: 16},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, null.IntFrom(255), hexId)
+
+	// Test out of range case
+	var rangeId null.Int
+	err = v.Validate([]*v.Value{
+		{Result: &rangeId, Name: "id", Input: "9223372036854775808"},
+	})
+	var fieldErr *v.FieldError
+	assert.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, v.CodeOutOfRange, fieldErr.Code)
+	assert.False(t, rangeId.Valid)
 }
 
 // TestNullString tests handling of a null.String as the result
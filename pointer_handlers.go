@@ -0,0 +1,45 @@
+package validator
+
+import (
+	"reflect"
+)
+
+// pointerHandler is the TypeHandler for a Result of type **T, used for optional fields.
+// Empty input sets the *T to nil, unless the Value is Optional, which leaves it untouched,
+// or a Checkbox, which the TypeHandler for *T sets from its presence instead.  Other input is
+// parsed into a newly allocated T by the TypeHandler for *T, and the *T is left as it was if
+// that fails.
+func pointerHandler(input string, value *Value) error {
+	ptr := reflect.ValueOf(value.Result).Elem()
+	if input == "" && !value.Checkbox {
+		if skipEmpty(input, value) {
+			return nil
+		}
+		ptr.Set(reflect.Zero(ptr.Type()))
+		return nil
+	}
+
+	// Find the element handler
+	elemResult := reflect.New(ptr.Type().Elem())
+	elemHandler := value.validatorOrDefault().typeHandlerFor(elemResult.Interface())
+	if elemHandler == nil {
+		return &UnsupportedTypeError{Name: value.Name, Type: reflect.TypeOf(value.Result)}
+	}
+
+	// Parse with a copy of the Value pointing at the new T, keeping its Base, layouts and State
+	elem := *value
+	elem.Result = elemResult.Interface()
+	elem.TypeHandler = elemHandler
+	err := elemHandler(input, &elem)
+	if err != nil {
+		return err
+	}
+	ptr.Set(elemResult)
+	return nil
+}
+
+// isPointerResult checks if a Result is of type **T
+func isPointerResult(result interface{}) bool {
+	t := reflect.TypeOf(result)
+	return t != nil && t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Ptr
+}
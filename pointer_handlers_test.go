@@ -0,0 +1,78 @@
+package validator_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	v "github.com/go-carrot/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPointers tests handling of pointers to supported types as the result
+func TestPointers(t *testing.T) {
+	// Test success case
+	var limit *int
+	var name *string
+	var since *time.Time
+	var ids *[]int
+	err := v.Validate([]*v.Value{
+		{Result: &limit, Name: "limit", Input: "10"},
+		{Result: &name, Name: "name", Input: "Brandon"},
+		{Result: &since, Name: "since", Input: "2012-11-01T22:08:41+00:00"},
+		{Result: &ids, Name: "id", Inputs: []string{"1", "2"}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 10, *limit)
+	assert.Equal(t, "Brandon", *name)
+	assert.Equal(t, 2012, since.Year())
+	assert.Equal(t, []int{1, 2}, *ids)
+
+	// Test empty case
+	var emptyLimit *int
+	var emptyName *string
+	err = v.Validate([]*v.Value{
+		{Result: &emptyLimit, Name: "limit", Input: ""},
+		{Result: &emptyName, Name: "name", Input: ""},
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, emptyLimit)
+	assert.Nil(t, emptyName)
+
+	// Test default case
+	var defaultLimit *int
+	err = v.Validate([]*v.Value{
+		{Result: &defaultLimit, Name: "limit", Default: "20"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 20, *defaultLimit)
+
+	// Test failure case
+	previous := 5
+	failureLimit := &previous
+	err = v.Validate([]*v.Value{
+		{Result: &failureLimit, Name: "limit", Input: "abc"},
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, "Invalid `limit` parameter, `limit` must be an int", err.Error())
+	assert.Equal(t, &previous, failureLimit)
+	assert.Equal(t, 5, previous)
+
+	// Test optional present but empty case, which leaves the pointer untouched
+	optionalLimit := &previous
+	optionalValue := &v.Value{Result: &optionalLimit, Name: "limit", Optional: true, State: v.InputPresent}
+	err = v.Validate([]*v.Value{optionalValue})
+	assert.Nil(t, err)
+	assert.Equal(t, &previous, optionalLimit)
+	assert.False(t, optionalValue.Supplied())
+}
+
+// TestUnknownPointer tests handling a pointer to a type that this library knows nothing about
+func TestUnknownPointer(t *testing.T) {
+	type Cat struct{ name string }
+	var cat *Cat
+	err := v.Validate([]*v.Value{
+		{Result: &cat, Name: "cat", Input: "rae"},
+	})
+	assert.True(t, errors.Is(err, v.ErrUnsupportedType))
+}
@@ -0,0 +1,38 @@
+package validator
+
+import (
+	"reflect"
+)
+
+// PostRule is a rule that runs after the TypeHandler, against the parsed Result of a Value
+// rather than against its raw input.  result is the Result of the Value, which is a pointer.
+type PostRule func(name string, result interface{}) error
+
+// Post builds a PostRule from a rule on the parsed value, where T is the type the Result
+// points to.  For a Value with Result &id, where id is an int, that is Post[int].
+//
+// If the Result doesn't point to a T, the PostRule returns a RuleTypeError.
+func Post[T any](rule func(name string, result T) error) PostRule {
+	return func(name string, result interface{}) error {
+		typed, ok := result.(*T)
+		if !ok {
+			return &RuleTypeError{Name: name, Type: reflect.TypeOf((*T)(nil)), Result: reflect.TypeOf(result)}
+		}
+		return rule(name, *typed)
+	}
+}
+
+// runPostRules runs the PostRules of a Value against its parsed Result, where a PostRule
+// that doesn't fit the Result is a misconfiguration rather than bad input
+func (v *Validator) runPostRules(value *Value, input string) error {
+	for _, rule := range value.PostRules {
+		err := rule(value.Name, value.Result)
+		if _, ok := err.(*RuleTypeError); ok {
+			return v.misconfigured(err)
+		}
+		if err != nil {
+			return v.format(ruleFailed(value, input, err))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package validator_test
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	v "github.com/go-carrot/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+// Max is a post rule that makes sure the parsed value isn't greater than max
+func Max(max int) v.PostRule {
+	return v.Post(func(name string, result int) error {
+		if result > max {
+			return fmt.Errorf("The value of %v may not be greater than %v", name, max)
+		}
+		return nil
+	})
+}
+
+// Matches is a post rule that makes sure the parsed value matches re
+func Matches(re *regexp.Regexp) v.PostRule {
+	return v.Post(func(name string, result string) error {
+		if !re.MatchString(result) {
+			return fmt.Errorf("The value of %v must match %v", name, re)
+		}
+		return nil
+	})
+}
+
+// TestPostRules tests rules that run against the parsed value
+func TestPostRules(t *testing.T) {
+	// Test success case
+	var id int
+	var code string
+	var timeout time.Duration
+	err := v.Validate([]*v.Value{
+		{Result: &id, Name: "id", Input: "0x0A", Base: v.BasePrefixed, PostRules: []v.PostRule{Max(10)}},
+		{Result: &code, Name: "code", Input: "abc", PostRules: []v.PostRule{Matches(regexp.MustCompile("^[a-z]+$"))}},
+		{Result: &timeout, Name: "timeout", Input: "30s", PostRules: []v.PostRule{v.Post(func(name string, result time.Duration) error {
+			if result > time.Minute {
+				return fmt.Errorf("%v is too long", name)
+			}
+			return nil
+		})}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 10, id)
+	assert.Equal(t, "abc", code)
+	assert.Equal(t, 30*time.Second, timeout)
+
+	// Test failure case
+	err = v.Validate([]*v.Value{
+		{Result: &id, Name: "id", Input: "0x0B", Base: v.BasePrefixed, PostRules: []v.PostRule{Max(10)}},
+	})
+	var fieldErr *v.FieldError
+	assert.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, v.CodeRuleFailed, fieldErr.Code)
+	assert.Equal(t, "0x0B", fieldErr.Input)
+	assert.Equal(t, "The value of id may not be greater than 10", err.Error())
+
+	// Test type mismatch case, where the post rule doesn't run
+	calls := 0
+	err = v.Validate([]*v.Value{
+		{Result: &id, Name: "id", Input: "abc", PostRules: []v.PostRule{v.Post(func(name string, result int) error {
+			calls++
+			return nil
+		})}},
+	})
+	assert.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, v.CodeTypeMismatch, fieldErr.Code)
+	assert.Equal(t, 0, calls)
+
+	// Test skipped case, where the post rule doesn't run
+	limit := 5
+	err = v.Validate([]*v.Value{
+		{Result: &limit, Name: "limit", Optional: true, PostRules: []v.PostRule{Max(1)}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 5, limit)
+
+	// Test default case, where the post rule runs against the parsed default
+	err = v.Validate([]*v.Value{
+		{Result: &limit, Name: "limit", Default: "20", PostRules: []v.PostRule{Max(10)}},
+	})
+	assert.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, v.CodeRuleFailed, fieldErr.Code)
+}
+
+// TestPostRuleType tests that a post rule for another type is a misconfiguration
+func TestPostRuleType(t *testing.T) {
+	// Test mismatch case
+	var id int64
+	err := v.Validate([]*v.Value{
+		{Result: &id, Name: "id", Input: "10", PostRules: []v.PostRule{Max(10)}},
+	})
+	assert.True(t, errors.Is(err, v.ErrRuleType))
+	var typeErr *v.RuleTypeError
+	assert.True(t, errors.As(err, &typeErr))
+	assert.Equal(t, "id", typeErr.Name)
+	assert.Equal(t, "go-carrot/validator: post rule for id expects a Result of type *int, not *int64", err.Error())
+
+	// Test strict case
+	validator := v.New(v.WithStrict())
+	assert.Panics(t, func() {
+		validator.Validate([]*v.Value{
+			{Result: &id, Name: "id", Input: "10", PostRules: []v.PostRule{Max(10)}},
+		})
+	})
+}
@@ -3,141 +3,386 @@ package validator
 import (
 	"errors"
 	"fmt"
+	"math"
 	"strconv"
+	"strings"
 	"time"
 )
 
+// BasePrefixed is a Base that parses integers with the prefix rules of Go integer
+// literals, so "0xff", "0o17", "0b101" and "1_000" are all allowed.
+const BasePrefixed = -1
+
+const (
+	// LayoutUnix is a time layout for Unix epoch seconds, such as "1351807721"
+	LayoutUnix = "unix"
+
+	// LayoutUnixMilli is a time layout for Unix epoch milliseconds, such as "1351807721000"
+	LayoutUnixMilli = "unixmilli"
+)
+
 func stringHandler(input string, value *Value) error {
+	err := checkJSONKind(input, value, JSONString, "a string")
+	if err != nil {
+		return err
+	}
 	*value.Result.(*string) = input
 	return nil
 }
 
 func float32Handler(input string, value *Value) error {
-	res, err := strconv.ParseFloat(input, 32)
+	if skipEmpty(input, value) {
+		return nil
+	}
+	res, err := parseFloat(input, value, 32, "a float32")
 	if err != nil {
-		return errors.New(invalidParam(value.Name, "a float32"))
+		return err
 	}
 	*value.Result.(*float32) = float32(res)
 	return nil
 }
 
 func float64Handler(input string, value *Value) error {
-	res, err := strconv.ParseFloat(input, 64)
+	if skipEmpty(input, value) {
+		return nil
+	}
+	res, err := parseFloat(input, value, 64, "a float64")
 	if err != nil {
-		return errors.New(invalidParam(value.Name, "a float64"))
+		return err
 	}
 	*value.Result.(*float64) = float64(res)
 	return nil
 }
 
 func boolHandler(input string, value *Value) error {
-	res, err := strconv.ParseBool(input)
+	if value.Checkbox {
+		*value.Result.(*bool) = value.Presence() == InputPresent
+		return nil
+	}
+	if skipEmpty(input, value) {
+		return nil
+	}
+	res, err := parseBool(input, value)
 	if err != nil {
-		return errors.New(invalidParam(value.Name, "a bool"))
+		return err
 	}
 	*value.Result.(*bool) = res
 	return nil
 }
 
 func intHandler(input string, value *Value) error {
-	res, err := strconv.ParseInt(input, 10, 0)
+	if skipEmpty(input, value) {
+		return nil
+	}
+	res, err := parseInt(input, value, 0, "an int")
 	if err != nil {
-		return errors.New(invalidParam(value.Name, "an int"))
+		return err
 	}
 	*value.Result.(*int) = int(res)
 	return nil
 }
 
 func int8Handler(input string, value *Value) error {
-	res, err := strconv.ParseInt(input, 10, 8)
+	if skipEmpty(input, value) {
+		return nil
+	}
+	res, err := parseInt(input, value, 8, "an int8")
 	if err != nil {
-		return errors.New(invalidParam(value.Name, "an int8"))
+		return err
 	}
 	*value.Result.(*int8) = int8(res)
 	return nil
 }
 
 func int16Handler(input string, value *Value) error {
-	res, err := strconv.ParseInt(input, 10, 16)
+	if skipEmpty(input, value) {
+		return nil
+	}
+	res, err := parseInt(input, value, 16, "an int16")
 	if err != nil {
-		return errors.New(invalidParam(value.Name, "an int16"))
+		return err
 	}
 	*value.Result.(*int16) = int16(res)
 	return nil
 }
 
 func int32Handler(input string, value *Value) error {
-	res, err := strconv.ParseInt(input, 10, 32)
+	if skipEmpty(input, value) {
+		return nil
+	}
+	res, err := parseInt(input, value, 32, "an int32")
 	if err != nil {
-		return errors.New(invalidParam(value.Name, "an int32"))
+		return err
 	}
 	*value.Result.(*int32) = int32(res)
 	return nil
 }
 
 func int64Handler(input string, value *Value) error {
-	res, err := strconv.ParseInt(input, 10, 64)
+	if skipEmpty(input, value) {
+		return nil
+	}
+	res, err := parseInt(input, value, 64, "an int64")
 	if err != nil {
-		return errors.New(invalidParam(value.Name, "an int64"))
+		return err
 	}
 	*value.Result.(*int64) = int64(res)
 	return nil
 }
 
 func uintHandler(input string, value *Value) error {
-	res, err := strconv.ParseUint(input, 10, 0)
+	if skipEmpty(input, value) {
+		return nil
+	}
+	res, err := parseUint(input, value, 0, "a uint")
 	if err != nil {
-		return errors.New(invalidParam(value.Name, "a uint"))
+		return err
 	}
 	*value.Result.(*uint) = uint(res)
 	return nil
 }
 
 func uint8Handler(input string, value *Value) error {
-	res, err := strconv.ParseUint(input, 10, 8)
+	if skipEmpty(input, value) {
+		return nil
+	}
+	res, err := parseUint(input, value, 8, "a uint8")
 	if err != nil {
-		return errors.New(invalidParam(value.Name, "a uint8"))
+		return err
 	}
 	*value.Result.(*uint8) = uint8(res)
 	return nil
 }
 
 func uint16Handler(input string, value *Value) error {
-	res, err := strconv.ParseUint(input, 10, 16)
+	if skipEmpty(input, value) {
+		return nil
+	}
+	res, err := parseUint(input, value, 16, "a uint16")
 	if err != nil {
-		return errors.New(invalidParam(value.Name, "a uint16"))
+		return err
 	}
 	*value.Result.(*uint16) = uint16(res)
 	return nil
 }
 
 func uint32Handler(input string, value *Value) error {
-	res, err := strconv.ParseUint(input, 10, 32)
+	if skipEmpty(input, value) {
+		return nil
+	}
+	res, err := parseUint(input, value, 32, "a uint32")
 	if err != nil {
-		return errors.New(invalidParam(value.Name, "a uint32"))
+		return err
 	}
 	*value.Result.(*uint32) = uint32(res)
 	return nil
 }
 
 func uint64Handler(input string, value *Value) error {
-	res, err := strconv.ParseUint(input, 10, 64)
+	if skipEmpty(input, value) {
+		return nil
+	}
+	res, err := parseUint(input, value, 64, "a uint64")
 	if err != nil {
-		return errors.New(invalidParam(value.Name, "a uint64"))
+		return err
 	}
 	*value.Result.(*uint64) = uint64(res)
 	return nil
 }
 
 func timeHandler(input string, value *Value) error {
-	res, err := time.Parse(time.RFC3339, input)
+	if skipEmpty(input, value) {
+		return nil
+	}
+	res, err := parseTime(input, value)
 	if err != nil {
-		return errors.New(invalidParam(value.Name, "an RFC 3339 date-time (2006-01-02T15:04:05Z07:00)"))
+		return err
 	}
 	*value.Result.(*time.Time) = res
 	return nil
 }
 
-func invalidParam(name string, mustBe string) string {
-	return fmt.Sprintf("Invalid `%v` parameter, `%v` must be %v", name, name, mustBe)
+// parseFloat parses a float of bitSize bits.  Unless its Validator allows them, NaN and
+// infinite values are rejected, and if the Value or its Validator disallow exponents, so is
+// exponent notation such as "1e3".
+func parseFloat(input string, value *Value, bitSize int, expected string) (float64, error) {
+	err := checkJSONKind(input, value, JSONNumber, expected)
+	if err != nil {
+		return 0, err
+	}
+	validator := value.validatorOrDefault()
+	if (value.NoExponent || validator.noExponent) && strings.ContainsAny(input, "eEpP") {
+		return 0, invalidParam(value, input, expected+" without an exponent")
+	}
+	res, err := strconv.ParseFloat(input, bitSize)
+	if err != nil {
+		return 0, invalidParam(value, input, expected)
+	}
+	if !validator.allowNonFinite && (math.IsNaN(res) || math.IsInf(res, 0)) {
+		return 0, invalidParam(value, input, "a finite "+strings.TrimPrefix(expected, "a "))
+	}
+	return res, nil
+}
+
+// parseBool parses a bool using the words of the Validator of the Value, matched case
+// insensitively, or with strconv.ParseBool if it has none
+func parseBool(input string, value *Value) (bool, error) {
+	err := checkJSONKind(input, value, JSONBool, "a bool")
+	if err != nil {
+		return false, err
+	}
+	words := value.validatorOrDefault().boolWords
+	if words == nil {
+		res, err := strconv.ParseBool(input)
+		if err != nil {
+			return false, invalidParam(value, input, "a bool")
+		}
+		return res, nil
+	}
+	for _, word := range words.truthy {
+		if strings.EqualFold(input, word) {
+			return true, nil
+		}
+	}
+	for _, word := range words.falsy {
+		if strings.EqualFold(input, word) {
+			return false, nil
+		}
+	}
+	all := append(append([]string{}, words.truthy...), words.falsy...)
+	return false, invalidParam(value, input, fmt.Sprintf("a bool (one of %v)", strings.Join(all, ", ")))
+}
+
+// parseInt parses a signed integer of bitSize bits, in the base of the Value, which
+// defaults to that of its Validator and then to 10
+func parseInt(input string, value *Value, bitSize int, expected string) (int64, error) {
+	err := checkIntJSONKind(input, value, expected)
+	if err != nil {
+		return 0, err
+	}
+	res, err := strconv.ParseInt(input, intBase(value), bitSize)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			if bitSize == 0 {
+				bitSize = strconv.IntSize
+			}
+			min, max := int64(-1)<<(bitSize-1), int64(1)<<(bitSize-1)-1
+			return 0, outOfRange(value, input, fmt.Sprintf("between %v and %v", min, max))
+		}
+		return 0, invalidParam(value, input, expected)
+	}
+	return res, nil
+}
+
+// parseUint parses an unsigned integer of bitSize bits, in the base of the Value, which
+// defaults to that of its Validator and then to 10
+func parseUint(input string, value *Value, bitSize int, expected string) (uint64, error) {
+	err := checkIntJSONKind(input, value, expected)
+	if err != nil {
+		return 0, err
+	}
+	res, err := strconv.ParseUint(input, intBase(value), bitSize)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			if bitSize == 0 {
+				bitSize = strconv.IntSize
+			}
+			max := uint64(1)<<(bitSize-1)<<1 - 1
+			return 0, outOfRange(value, input, fmt.Sprintf("between 0 and %v", max))
+		}
+		return 0, invalidParam(value, input, expected)
+	}
+	return res, nil
+}
+
+// intBase returns the base integers of a Value are parsed in, as used by strconv
+func intBase(value *Value) int {
+	base := value.Base
+	if base == 0 {
+		base = value.validatorOrDefault().base
+	}
+	switch base {
+	case 0:
+		return 10
+	case BasePrefixed:
+		return 0
+	}
+	return base
+}
+
+// checkIntJSONKind checks that an integer input was a JSON number, or a JSON string if the
+// Value parses integers in another base than 10, which JSON numbers can't be written in
+func checkIntJSONKind(input string, value *Value, expected string) error {
+	if value.JSONKind == JSONString && intBase(value) != 10 {
+		return nil
+	}
+	return checkJSONKind(input, value, JSONNumber, expected)
+}
+
+// checkBase checks that the base a Value parses integers in, which defaults to that of the
+// Validator, is one strconv accepts
+func (v *Validator) checkBase(value *Value) error {
+	base := value.Base
+	if base == 0 {
+		base = v.base
+	}
+	if base == 0 || base == BasePrefixed || (base >= 2 && base <= 36) {
+		return nil
+	}
+	return &InvalidBaseError{Name: value.Name, Base: base}
+}
+
+// parseTime parses a time.Time using the time layouts and location of the Value, which
+// default to those of its Validator, and then to RFC 3339 in UTC
+func parseTime(input string, value *Value) (time.Time, error) {
+	layouts := value.TimeLayouts
+	if len(layouts) == 0 {
+		layouts = value.validatorOrDefault().timeLayouts
+	}
+	if len(layouts) == 0 {
+		layouts = []string{time.RFC3339}
+	}
+	location := value.Location
+	if location == nil {
+		location = value.validatorOrDefault().location
+	}
+	if location == nil {
+		location = time.UTC
+	}
+
+	// Try each layout in turn
+	for _, layout := range layouts {
+		switch layout {
+		case LayoutUnix, LayoutUnixMilli:
+			res, err := strconv.ParseInt(input, 10, 64)
+			if err != nil {
+				continue
+			}
+			if layout == LayoutUnix {
+				return time.Unix(res, 0).In(location), nil
+			}
+			return time.UnixMilli(res).In(location), nil
+		default:
+			res, err := time.ParseInLocation(layout, input, location)
+			if err == nil {
+				return res, nil
+			}
+		}
+	}
+
+	// Describe the accepted layouts
+	if len(layouts) == 1 && layouts[0] == time.RFC3339 {
+		return time.Time{}, invalidParam(value, input, "an RFC 3339 date-time (2006-01-02T15:04:05Z07:00)")
+	}
+	return time.Time{}, invalidParam(value, input, fmt.Sprintf("a date-time in one of the layouts %v", strings.Join(layouts, ", ")))
+}
+
+// skipEmpty reports whether the input of an Optional Value is empty, in which case
+// the handler should skip parsing and leave the Result untouched
+func skipEmpty(input string, value *Value) bool {
+	if value.Optional && input == "" {
+		value.skipped = true
+		return true
+	}
+	return false
 }
@@ -1,11 +1,14 @@
 package validator_test
 
 import (
+	"errors"
 	"testing"
 	"time"
 
 	v "github.com/go-carrot/validator"
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/guregu/null.v3"
+	"gopkg.in/guregu/null.v3/zero"
 )
 
 // TestString tests handling of a string as the result
@@ -629,3 +632,384 @@ func TestTime(t *testing.T) {
 	})
 	assert.NotNil(t, err)
 }
+
+// TestOptionalEmpty tests that optional values with empty input skip parsing
+func TestOptionalEmpty(t *testing.T) {
+	// Test empty case
+	limit := 5
+	price := float64(1.5)
+	admin := true
+	var since time.Time
+	limitValue := &v.Value{Result: &limit, Name: "limit", Input: "", State: v.InputPresent, Optional: true}
+	err := v.Validate([]*v.Value{
+		limitValue,
+		{Result: &price, Name: "price", Input: "", State: v.InputPresent, Optional: true},
+		{Result: &admin, Name: "admin", Input: "", State: v.InputPresent, Optional: true},
+		{Result: &since, Name: "since", Input: "", State: v.InputPresent, Optional: true},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 5, limit)
+	assert.Equal(t, float64(1.5), price)
+	assert.True(t, admin)
+	assert.True(t, since.IsZero())
+	assert.False(t, limitValue.Supplied())
+
+	// Test empty string case, which is still assigned
+	name := "unchanged"
+	nameValue := &v.Value{Result: &name, Name: "name", Input: "", State: v.InputPresent, Optional: true}
+	err = v.Validate([]*v.Value{nameValue})
+	assert.Nil(t, err)
+	assert.Equal(t, "", name)
+	assert.True(t, nameValue.Supplied())
+
+	// Test supplied case
+	suppliedValue := &v.Value{Result: &limit, Name: "limit", Input: "10", Optional: true}
+	err = v.Validate([]*v.Value{suppliedValue})
+	assert.Nil(t, err)
+	assert.Equal(t, 10, limit)
+	assert.True(t, suppliedValue.Supplied())
+
+	// Test failure case
+	failureValue := &v.Value{Result: &limit, Name: "limit", Input: "abc", Optional: true}
+	err = v.Validate([]*v.Value{failureValue})
+	assert.NotNil(t, err)
+	assert.Equal(t, 10, limit)
+	assert.False(t, failureValue.Supplied())
+
+	// Test absent case
+	absentValue := &v.Value{Result: &limit, Name: "limit", Optional: true}
+	err = v.Validate([]*v.Value{absentValue})
+	assert.Nil(t, err)
+	assert.Equal(t, 10, limit)
+	assert.False(t, absentValue.Supplied())
+
+	// Test not optional case
+	var requiredLimit int
+	err = v.Validate([]*v.Value{
+		{Result: &requiredLimit, Name: "limit", Input: "", State: v.InputPresent},
+	})
+	assert.NotNil(t, err)
+}
+
+// TestTimeLayouts tests handling of a time.Time with custom layouts
+func TestTimeLayouts(t *testing.T) {
+	layouts := []string{"2006-01-02", time.RFC1123, v.LayoutUnix}
+
+	// Test success cases
+	var date, rfc1123, unix time.Time
+	err := v.Validate([]*v.Value{
+		{Result: &date, Name: "date", Input: "2012-11-01", TimeLayouts: layouts},
+		{Result: &rfc1123, Name: "rfc1123", Input: "Thu, 01 Nov 2012 22:08:41 UTC", TimeLayouts: layouts},
+		{Result: &unix, Name: "unix", Input: "1351807721", TimeLayouts: layouts},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2012, time.November, 1, 0, 0, 0, 0, time.UTC), date)
+	assert.Equal(t, int64(1351807721), rfc1123.Unix())
+	assert.Equal(t, int64(1351807721), unix.Unix())
+
+	// Test unix milliseconds
+	var unixMilli time.Time
+	err = v.Validate([]*v.Value{
+		{Result: &unixMilli, Name: "time", Input: "1351807721500", TimeLayouts: []string{v.LayoutUnixMilli}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1351807721500), unixMilli.UnixMilli())
+
+	// Test failure case
+	var errorTime time.Time
+	err = v.Validate([]*v.Value{
+		{Result: &errorTime, Name: "time", Input: "2012-11-01T22:08:41+00:00", TimeLayouts: layouts},
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, "Invalid `time` parameter, `time` must be a date-time in one of the layouts 2006-01-02, Mon, 02 Jan 2006 15:04:05 MST, unix", err.Error())
+	assert.True(t, errorTime.IsZero())
+}
+
+// TestTimeValidatorLayouts tests handling of a time.Time with the layouts and location of a Validator
+func TestTimeValidatorLayouts(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("time zone database unavailable")
+	}
+	validator := v.New(v.WithTimeLayouts("2006-01-02 15:04"), v.WithLocation(newYork))
+
+	// Test success case
+	var successTime time.Time
+	err = validator.Validate([]*v.Value{
+		{Result: &successTime, Name: "time", Input: "2012-11-01 22:08"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2012, time.November, 1, 22, 8, 0, 0, newYork), successTime)
+
+	// Test Value layouts override the Validator
+	var valueTime time.Time
+	err = validator.Validate([]*v.Value{
+		{Result: &valueTime, Name: "time", Input: "2012-11-01 22:08", TimeLayouts: []string{time.RFC3339}, Location: time.UTC},
+	})
+	assert.NotNil(t, err)
+
+	// Test null.Time
+	var nullTime null.Time
+	err = validator.Validate([]*v.Value{
+		{Result: &nullTime, Name: "time", Input: "2012-11-01 22:08"},
+	})
+	assert.Nil(t, err)
+	assert.True(t, nullTime.Valid)
+	assert.Equal(t, time.Date(2012, time.November, 1, 22, 8, 0, 0, newYork), nullTime.Time)
+}
+
+// TestIntBase tests parsing integers in other bases
+func TestIntBase(t *testing.T) {
+	// Test prefixed case
+	var hex, octal, binary, separated int64
+	var mode uint32
+	err := v.Validate([]*v.Value{
+		{Result: &hex, Name: "hex", Input: "0xFF", Base: v.BasePrefixed},
+		{Result: &octal, Name: "octal", Input: "0o17", Base: v.BasePrefixed},
+		{Result: &binary, Name: "binary", Input: "-0b101", Base: v.BasePrefixed},
+		{Result: &separated, Name: "separated", Input: "1_000_000", Base: v.BasePrefixed},
+		{Result: &mode, Name: "mode", Input: "0755", Base: v.BasePrefixed},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(255), hex)
+	assert.Equal(t, int64(15), octal)
+	assert.Equal(t, int64(-5), binary)
+	assert.Equal(t, int64(1000000), separated)
+	assert.Equal(t, uint32(493), mode)
+
+	// Test fixed base case
+	var fixed int
+	err = v.Validate([]*v.Value{
+		{Result: &fixed, Name: "fixed", Input: "ff", Base: 16},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 255, fixed)
+
+	// Test base of a Validator
+	var validatorHex uint8
+	err = v.New(v.WithBase(v.BasePrefixed)).Validate([]*v.Value{
+		{Result: &validatorHex, Name: "hex", Input: "0x1f"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, uint8(31), validatorHex)
+
+	// Test default base rejects prefixes and separators
+	var decimal int
+	err = v.Validate([]*v.Value{
+		{Result: &decimal, Name: "decimal", Input: "1_000"},
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, "Invalid `decimal` parameter, `decimal` must be an int", err.Error())
+}
+
+// TestInvalidBase tests that a base strconv doesn't accept is a misconfiguration
+func TestInvalidBase(t *testing.T) {
+	// Test base of a Value
+	var n int
+	err := v.Validate([]*v.Value{
+		{Result: &n, Name: "n", Input: "10", Base: 99},
+	})
+	assert.True(t, errors.Is(err, v.ErrInvalidBase))
+	var fieldErr *v.FieldError
+	assert.False(t, errors.As(err, &fieldErr))
+	assert.Equal(t, "go-carrot/validator: invalid base 99 for n, which must be BasePrefixed or from 2 to 36", err.Error())
+
+	// Test base of a Validator
+	validator := v.New(v.WithBase(1))
+	err = validator.Validate([]*v.Value{
+		{Result: &n, Name: "n", Input: "10"},
+	})
+	var baseErr *v.InvalidBaseError
+	assert.True(t, errors.As(err, &baseErr))
+	assert.Equal(t, 1, baseErr.Base)
+
+	// Test Check case, which doesn't need an input
+	err = validator.Check([]*v.Value{
+		{Result: &n, Name: "n"},
+	})
+	assert.True(t, errors.Is(err, v.ErrInvalidBase))
+
+	// Test strict case
+	assert.Panics(t, func() {
+		v.New(v.WithStrict(), v.WithBase(37)).Validate([]*v.Value{
+			{Result: &n, Name: "n", Input: "10"},
+		})
+	})
+}
+
+// TestIntRange tests that integers that don't fit the result report the allowed range
+func TestIntRange(t *testing.T) {
+	cases := []struct {
+		result   interface{}
+		input    string
+		expected string
+	}{
+		{new(int8), "128", "between -128 and 127"},
+		{new(int8), "-129", "between -128 and 127"},
+		{new(int16), "40000", "between -32768 and 32767"},
+		{new(int32), "3000000000", "between -2147483648 and 2147483647"},
+		{new(int64), "9223372036854775808", "between -9223372036854775808 and 9223372036854775807"},
+		{new(uint8), "256", "between 0 and 255"},
+		{new(uint16), "70000", "between 0 and 65535"},
+		{new(uint64), "18446744073709551616", "between 0 and 18446744073709551615"},
+	}
+	for _, c := range cases {
+		err := v.Validate([]*v.Value{
+			{Result: c.result, Name: "n", Input: c.input},
+		})
+		assert.NotNil(t, err, c.input)
+		assert.Equal(t, "Invalid `n` parameter, `n` must be "+c.expected, err.Error())
+
+		var fieldErr *v.FieldError
+		assert.True(t, errors.As(err, &fieldErr))
+		assert.Equal(t, v.CodeOutOfRange, fieldErr.Code)
+	}
+}
+
+// TestNonFiniteFloat tests that a new Validator rejects NaN and infinite floats
+func TestNonFiniteFloat(t *testing.T) {
+	validator := v.New()
+	for _, input := range []string{"NaN", "Inf", "+Inf", "-Infinity"} {
+		// Test strict case
+		var price float64
+		var nullPrice null.Float
+		var smallPrice float32
+		err := validator.Validate([]*v.Value{
+			{Result: &price, Name: "price", Input: input},
+		})
+		assert.NotNil(t, err, input)
+		assert.Equal(t, "Invalid `price` parameter, `price` must be a finite float64", err.Error())
+		err = validator.Validate([]*v.Value{
+			{Result: &nullPrice, Name: "price", Input: input},
+		})
+		assert.NotNil(t, err, input)
+		assert.False(t, nullPrice.Valid)
+		err = validator.Validate([]*v.Value{
+			{Result: &smallPrice, Name: "price", Input: input},
+		})
+		assert.NotNil(t, err, input)
+
+		// Test allowed case
+		err = v.New(v.WithNonFiniteFloats()).Validate([]*v.Value{
+			{Result: &price, Name: "price", Input: input},
+		})
+		assert.Nil(t, err, input)
+
+		// Test package-level case
+		err = v.Validate([]*v.Value{
+			{Result: &price, Name: "price", Input: input},
+		})
+		assert.Nil(t, err, input)
+	}
+
+	// Test finite case
+	var price float64
+	err := validator.Validate([]*v.Value{
+		{Result: &price, Name: "price", Input: "1e3"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, float64(1000), price)
+}
+
+// TestNoExponentFloat tests rejecting floats in exponent notation
+func TestNoExponentFloat(t *testing.T) {
+	// Test Value case
+	var price float64
+	err := v.Validate([]*v.Value{
+		{Result: &price, Name: "price", Input: "1e3", NoExponent: true},
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, "Invalid `price` parameter, `price` must be a float64 without an exponent", err.Error())
+
+	// Test Validator case
+	var nullPrice null.Float
+	err = v.New(v.WithNoExponent()).Validate([]*v.Value{
+		{Result: &nullPrice, Name: "price", Input: "0x1p-2"},
+	})
+	assert.NotNil(t, err)
+
+	// Test success case
+	err = v.Validate([]*v.Value{
+		{Result: &price, Name: "price", Input: "12.50", NoExponent: true},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 12.5, price)
+}
+
+// TestBoolWords tests handling of a bool with custom words
+func TestBoolWords(t *testing.T) {
+	validator := v.New(v.WithBoolWords([]string{"yes", "y", "on"}, []string{"no", "n", "off"}))
+
+	// Test success cases
+	cases := map[string]bool{"yes": true, "Y": true, "ON": true, "no": false, "N": false, "Off": false}
+	for input, expected := range cases {
+		var flag bool
+		var nullFlag null.Bool
+		err := validator.Validate([]*v.Value{
+			{Result: &flag, Name: "flag", Input: input},
+			{Result: &nullFlag, Name: "flag", Input: input},
+		})
+		assert.Nil(t, err, input)
+		assert.Equal(t, expected, flag, input)
+		assert.Equal(t, null.BoolFrom(expected), nullFlag, input)
+	}
+
+	// Test failure case
+	var flag bool
+	err := validator.Validate([]*v.Value{
+		{Result: &flag, Name: "flag", Input: "true"},
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, "Invalid `flag` parameter, `flag` must be a bool (one of yes, y, on, no, n, off)", err.Error())
+}
+
+// TestCheckbox tests handling of a bool as an HTML checkbox
+func TestCheckbox(t *testing.T) {
+	// Test checked case
+	var checked bool
+	var nullChecked null.Bool
+	var zeroChecked zero.Bool
+	var pointerChecked *bool
+	var namedChecked Enabled
+	err := v.Validate([]*v.Value{
+		{Result: &checked, Name: "agree", Input: "on", Checkbox: true},
+		{Result: &nullChecked, Name: "agree", State: v.InputPresent, Checkbox: true},
+		{Result: &zeroChecked, Name: "agree", State: v.InputPresent, Checkbox: true},
+		{Result: &pointerChecked, Name: "agree", State: v.InputPresent, Checkbox: true},
+		{Result: &namedChecked, Name: "agree", State: v.InputPresent, Checkbox: true},
+	})
+	assert.Nil(t, err)
+	assert.True(t, checked)
+	assert.Equal(t, null.BoolFrom(true), nullChecked)
+	assert.Equal(t, zero.BoolFrom(true), zeroChecked)
+	assert.True(t, *pointerChecked)
+	assert.Equal(t, Enabled(true), namedChecked)
+
+	// Test unchecked case
+	unchecked := true
+	var nullUnchecked null.Bool
+	zeroUnchecked := zero.BoolFrom(true)
+	var pointerUnchecked *bool
+	namedUnchecked := Enabled(true)
+	err = v.Validate([]*v.Value{
+		{Result: &unchecked, Name: "agree", Checkbox: true},
+		{Result: &nullUnchecked, Name: "agree", Checkbox: true},
+		{Result: &zeroUnchecked, Name: "agree", Checkbox: true},
+		{Result: &pointerUnchecked, Name: "agree", Checkbox: true},
+		{Result: &namedUnchecked, Name: "agree", Checkbox: true},
+	})
+	assert.Nil(t, err)
+	assert.False(t, unchecked)
+	assert.Equal(t, null.BoolFrom(false), nullUnchecked)
+	assert.Equal(t, zero.BoolFrom(false), zeroUnchecked)
+	assert.False(t, *pointerUnchecked)
+	assert.Equal(t, Enabled(false), namedUnchecked)
+
+	// Test optional unchecked case, where absence still means false
+	optionalUnchecked := true
+	err = v.Validate([]*v.Value{
+		{Result: &optionalUnchecked, Name: "agree", Optional: true, Checkbox: true},
+	})
+	assert.Nil(t, err)
+	assert.False(t, optionalUnchecked)
+}
@@ -0,0 +1,52 @@
+package validator
+
+import (
+	"reflect"
+	"sync"
+)
+
+// typeHandlers holds the TypeHandlers registered with RegisterTypeHandler
+var typeHandlers = newRegistry()
+
+// RegisterTypeHandler makes handler the default TypeHandler for every Value with a
+// Result of type *T, where T is the type t.  For example, to handle uuid.UUID everywhere:
+//
+//	validator.RegisterTypeHandler(reflect.TypeOf(uuid.UUID{}), uuidHandler)
+//
+// Registered handlers take precedence over the built-in ones, and passing a nil handler
+// removes a registration.  RegisterTypeHandler is safe for concurrent use.
+func RegisterTypeHandler(t reflect.Type, handler TypeHandler) {
+	defaultValidator.RegisterTypeHandler(t, handler)
+}
+
+// registry is a concurrency safe map of Result element types to TypeHandlers
+type registry struct {
+	mu       sync.RWMutex
+	handlers map[reflect.Type]TypeHandler
+}
+
+func newRegistry() *registry {
+	return &registry{handlers: map[reflect.Type]TypeHandler{}}
+}
+
+func (r *registry) register(t reflect.Type, handler TypeHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if handler == nil {
+		delete(r.handlers, t)
+		return
+	}
+	r.handlers[t] = handler
+}
+
+// lookup finds the handler registered for the type a Result points to
+func (r *registry) lookup(result interface{}) (TypeHandler, bool) {
+	t := reflect.TypeOf(result)
+	if t == nil || t.Kind() != reflect.Ptr {
+		return nil, false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	handler, ok := r.handlers[t.Elem()]
+	return handler, ok
+}
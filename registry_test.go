@@ -0,0 +1,105 @@
+package validator_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	v "github.com/go-carrot/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+// Color is a custom type used to test registered type handlers
+type Color struct {
+	R, G, B uint8
+}
+
+// colorHandler parses a Color from a comma separated "r,g,b" input
+func colorHandler(input string, value *v.Value) error {
+	parts := strings.Split(input, ",")
+	if len(parts) != 3 {
+		return errors.New("Invalid color")
+	}
+	var color Color
+	err := v.Validate([]*v.Value{
+		{Result: &color.R, Name: value.Name, Input: parts[0]},
+		{Result: &color.G, Name: value.Name, Input: parts[1]},
+		{Result: &color.B, Name: value.Name, Input: parts[2]},
+	})
+	if err != nil {
+		return err
+	}
+	*value.Result.(*Color) = color
+	return nil
+}
+
+// TestRegisterTypeHandler tests that a registered type handler is applied automatically
+func TestRegisterTypeHandler(t *testing.T) {
+	// Test before registering
+	var color Color
+	err := v.Validate([]*v.Value{
+		{Result: &color, Name: "color", Input: "255,0,10"},
+	})
+	assert.True(t, errors.Is(err, v.ErrUnsupportedType))
+
+	// Test success case
+	v.RegisterTypeHandler(reflect.TypeOf(Color{}), colorHandler)
+	defer v.RegisterTypeHandler(reflect.TypeOf(Color{}), nil)
+	err = v.Validate([]*v.Value{
+		{Result: &color, Name: "color", Input: "255,0,10"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, Color{255, 0, 10}, color)
+
+	// Test failure case
+	var failureColor Color
+	err = v.Validate([]*v.Value{
+		{Result: &failureColor, Name: "color", Input: "255,0"},
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, Color{}, failureColor)
+}
+
+// TestRegisterTypeHandlerOverride tests that a registered type handler
+// takes precedence over a built-in one
+func TestRegisterTypeHandlerOverride(t *testing.T) {
+	v.RegisterTypeHandler(reflect.TypeOf(""), func(input string, value *v.Value) error {
+		*value.Result.(*string) = strings.ToUpper(input)
+		return nil
+	})
+	defer v.RegisterTypeHandler(reflect.TypeOf(""), nil)
+
+	var name string
+	err := v.Validate([]*v.Value{
+		{Result: &name, Name: "name", Input: "brandon"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "BRANDON", name)
+}
+
+// TestRegisterTypeHandlerConcurrent tests that registering and validating
+// may happen at the same time
+func TestRegisterTypeHandlerConcurrent(t *testing.T) {
+	type Concurrent struct{}
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			v.RegisterTypeHandler(reflect.TypeOf(Concurrent{}), func(input string, value *v.Value) error {
+				return nil
+			})
+		}()
+		go func() {
+			defer wg.Done()
+			var id int
+			v.Validate([]*v.Value{
+				{Result: &id, Name: "id", Input: "1"},
+			})
+		}()
+	}
+	wg.Wait()
+	v.RegisterTypeHandler(reflect.TypeOf(Concurrent{}), nil)
+}
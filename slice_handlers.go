@@ -0,0 +1,143 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// SliceOption configures a TypeHandler created with SliceOf.
+type SliceOption func(*sliceOptions)
+
+type sliceOptions struct {
+	trimSpace bool
+	skipEmpty bool
+	minItems  int
+	maxItems  int
+}
+
+// TrimSpace trims leading and trailing white space from each element.
+func TrimSpace() SliceOption {
+	return func(opts *sliceOptions) {
+		opts.trimSpace = true
+	}
+}
+
+// SkipEmpty drops empty elements, so that "a,,b" parses into two elements.
+func SkipEmpty() SliceOption {
+	return func(opts *sliceOptions) {
+		opts.skipEmpty = true
+	}
+}
+
+// MinItems requires at least n elements.
+func MinItems(n int) SliceOption {
+	return func(opts *sliceOptions) {
+		opts.minItems = n
+	}
+}
+
+// MaxItems allows at most n elements.
+func MaxItems(n int) SliceOption {
+	return func(opts *sliceOptions) {
+		opts.maxItems = n
+	}
+}
+
+// SliceOf creates a TypeHandler for a Result of type *[]T that splits its input on sep,
+// such as "1,2,3" or "a|b|c", and parses each element with elemHandler.  If elemHandler is
+// nil, the TypeHandler for *T is used.  When the Value has multiple Inputs, each of them is split.
+func SliceOf(elemHandler TypeHandler, sep string, opts ...SliceOption) TypeHandler {
+	options := sliceOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return func(input string, value *Value) error {
+		inputs := value.Inputs
+		if len(inputs) == 0 && input != "" {
+			inputs = []string{input}
+		}
+
+		// Split each input into its elements
+		var elems []string
+		for _, input := range inputs {
+			for _, elem := range strings.Split(input, sep) {
+				if options.trimSpace {
+					elem = strings.TrimSpace(elem)
+				}
+				if options.skipEmpty && elem == "" {
+					continue
+				}
+				elems = append(elems, elem)
+			}
+		}
+
+		// Check the number of elements
+		if len(elems) < options.minItems {
+			return itemCount(value, input, fmt.Sprintf("a list of at least %v items", options.minItems))
+		}
+		if options.maxItems > 0 && len(elems) > options.maxItems {
+			return itemCount(value, input, fmt.Sprintf("a list of at most %v items", options.maxItems))
+		}
+		return parseSlice(elems, elemHandler, value)
+	}
+}
+
+// itemCount builds the FieldError returned when a list has too few or too many elements
+func itemCount(value *Value, input string, expected string) error {
+	return &FieldError{Name: value.Name, Input: input, Expected: expected, Code: CodeItemCount}
+}
+
+// sliceHandler is the TypeHandler for a Result of type *[]T.  Each of the Inputs of the
+// Value, or the single input if there are none, is parsed by the TypeHandler for *T.
+func sliceHandler(input string, value *Value) error {
+	inputs := value.Inputs
+	if len(inputs) == 0 && input != "" {
+		inputs = []string{input}
+	}
+	return parseSlice(inputs, nil, value)
+}
+
+// parseSlice parses each input with elemHandler into a new slice, which is only
+// stored into the Result of the Value if every element is valid.  If elemHandler
+// is nil, the TypeHandler for the element type is used.
+func parseSlice(inputs []string, elemHandler TypeHandler, value *Value) error {
+	slice := reflect.ValueOf(value.Result).Elem()
+	if len(inputs) == 0 {
+		slice.Set(reflect.Zero(slice.Type()))
+		return nil
+	}
+
+	// Find the element handler
+	if elemHandler == nil {
+		elemHandler = value.validatorOrDefault().typeHandlerFor(reflect.New(slice.Type().Elem()).Interface())
+		if elemHandler == nil {
+			return &UnsupportedTypeError{Name: value.Name, Type: reflect.TypeOf(value.Result)}
+		}
+	}
+
+	// Parse each element with a copy of the Value named after its index, so an error says which
+	// element failed
+	result := reflect.MakeSlice(slice.Type(), len(inputs), len(inputs))
+	for i, input := range inputs {
+		elem := *value
+		elem.Result = result.Index(i).Addr().Interface()
+		elem.Name = fmt.Sprintf("%v[%v]", value.Name, i)
+		elem.Input = input
+		elem.Inputs = nil
+		elem.Optional = false
+		elem.TypeHandler = elemHandler
+		err := elemHandler(input, &elem)
+		if err != nil {
+			return err
+		}
+	}
+	slice.Set(result)
+	return nil
+}
+
+// isSliceResult checks if a Result is of type *[]T
+func isSliceResult(result interface{}) bool {
+	t := reflect.TypeOf(result)
+	return t != nil && t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Slice
+}
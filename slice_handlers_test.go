@@ -0,0 +1,199 @@
+package validator_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	v "github.com/go-carrot/validator"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/guregu/null.v3"
+)
+
+// TestStringSlice tests handling of a []string as the result
+func TestStringSlice(t *testing.T) {
+	// Test success case
+	var names []string
+	err := v.Validate([]*v.Value{
+		{Result: &names, Name: "name", Inputs: []string{"a", "b", "c"}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, names)
+
+	// Test single input case
+	var singleNames []string
+	err = v.Validate([]*v.Value{
+		{Result: &singleNames, Name: "name", Input: "a"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a"}, singleNames)
+
+	// Test default case
+	var defaultNames []string
+	err = v.Validate([]*v.Value{
+		{Result: &defaultNames, Name: "name", Default: "z"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"z"}, defaultNames)
+
+	// Test empty case
+	var emptyNames []string
+	err = v.Validate([]*v.Value{
+		{Result: &emptyNames, Name: "name"},
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, emptyNames)
+
+	// Test rules run against each input
+	var ruleNames []string
+	err = v.Validate([]*v.Value{
+		{Result: &ruleNames, Name: "name", Inputs: []string{"a", ""}, Rules: []v.Rule{IsSet}},
+	})
+	assert.NotNil(t, err)
+	assert.Nil(t, ruleNames)
+}
+
+// TestIntSlice tests handling of a []int as the result
+func TestIntSlice(t *testing.T) {
+	// Test success case
+	var ids []int
+	err := v.Validate([]*v.Value{
+		{Result: &ids, Name: "id", Inputs: []string{"1", "2", "3"}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 2, 3}, ids)
+
+	// Test failure case
+	var failureIds []int
+	err = v.Validate([]*v.Value{
+		{Result: &failureIds, Name: "id", Inputs: []string{"1", "b", "3"}},
+	})
+	assert.NotNil(t, err)
+	assert.Nil(t, failureIds)
+	assert.Equal(t, "Invalid `id[1]` parameter, `id[1]` must be an int", err.Error())
+
+	var fieldErr *v.FieldError
+	assert.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, "id[1]", fieldErr.Name)
+	assert.Equal(t, "b", fieldErr.Input)
+
+	// Test rule failure case
+	var ruleIds []int
+	err = v.Validate([]*v.Value{
+		{Result: &ruleIds, Name: "id", Inputs: []string{"1", "30"}, Rules: []v.Rule{MaxVal(20)}},
+	})
+	assert.NotNil(t, err)
+	assert.Nil(t, ruleIds)
+}
+
+// TestOtherSlices tests handling of slices of other supported types as the result
+func TestOtherSlices(t *testing.T) {
+	var ids []int64
+	var prices []float64
+	var flags []bool
+	var times []time.Time
+	var counts []null.Int
+	err := v.Validate([]*v.Value{
+		{Result: &ids, Name: "id", Inputs: []string{"1", "-2"}},
+		{Result: &prices, Name: "price", Inputs: []string{"1.5", "2"}},
+		{Result: &flags, Name: "flag", Inputs: []string{"true", "false"}},
+		{Result: &times, Name: "time", Inputs: []string{"2012-11-01T22:08:41+00:00"}},
+		{Result: &counts, Name: "count", Inputs: []string{"1", ""}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{1, -2}, ids)
+	assert.Equal(t, []float64{1.5, 2}, prices)
+	assert.Equal(t, []bool{true, false}, flags)
+	assert.Len(t, times, 1)
+	assert.Equal(t, 2012, times[0].Year())
+	assert.Equal(t, []null.Int{null.IntFrom(1), {}}, counts)
+}
+
+// TestUnknownSlice tests handling a slice of a type that this library knows nothing about
+func TestUnknownSlice(t *testing.T) {
+	type Cat struct{ name string }
+	var cats []Cat
+	err := v.Validate([]*v.Value{
+		{Result: &cats, Name: "cat", Inputs: []string{"rae"}},
+	})
+	assert.True(t, errors.Is(err, v.ErrUnsupportedType))
+}
+
+// TestSliceOf tests splitting input on a delimiter
+func TestSliceOf(t *testing.T) {
+	// Test success case
+	var ids []int
+	err := v.Validate([]*v.Value{
+		{Result: &ids, Name: "id", Input: "1|2|3", TypeHandler: v.SliceOf(nil, "|")},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 2, 3}, ids)
+
+	// Test multiple inputs case
+	var multipleIds []int
+	err = v.Validate([]*v.Value{
+		{Result: &multipleIds, Name: "id", Inputs: []string{"1,2", "3"}, TypeHandler: v.SliceOf(nil, ",")},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 2, 3}, multipleIds)
+
+	// Test failure case
+	var failureIds []int
+	err = v.Validate([]*v.Value{
+		{Result: &failureIds, Name: "id", Input: "1, 2", TypeHandler: v.SliceOf(nil, ",")},
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, "Invalid `id[1]` parameter, `id[1]` must be an int", err.Error())
+	assert.Nil(t, failureIds)
+
+	// Test custom element handler case
+	var fields []string
+	err = v.Validate([]*v.Value{
+		{Result: &fields, Name: "fields", Input: "a,b", TypeHandler: v.SliceOf(func(input string, value *v.Value) error {
+			*value.Result.(*string) = "_" + input
+			return nil
+		}, ",")},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"_a", "_b"}, fields)
+}
+
+// TestSliceOfOptions tests the options of SliceOf
+func TestSliceOfOptions(t *testing.T) {
+	// Test trim space and skip empty
+	var ids []int
+	err := v.Validate([]*v.Value{
+		{Result: &ids, Name: "id", Input: " 1, 2,, 3 ,", TypeHandler: v.SliceOf(nil, ",", v.TrimSpace(), v.SkipEmpty())},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 2, 3}, ids)
+
+	// Test empty elements are kept by default
+	var fields []string
+	err = v.Validate([]*v.Value{
+		{Result: &fields, Name: "fields", Input: "a,,b", TypeHandler: v.SliceOf(nil, ",")},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "", "b"}, fields)
+
+	// Test min items
+	var minIds []int
+	err = v.Validate([]*v.Value{
+		{Result: &minIds, Name: "id", Input: "1", TypeHandler: v.SliceOf(nil, ",", v.MinItems(2))},
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, "Invalid `id` parameter, `id` must be a list of at least 2 items", err.Error())
+
+	var fieldErr *v.FieldError
+	assert.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, v.CodeItemCount, fieldErr.Code)
+
+	// Test max items
+	var maxIds []int
+	err = v.Validate([]*v.Value{
+		{Result: &maxIds, Name: "id", Input: "1,2,3", TypeHandler: v.SliceOf(nil, ",", v.MaxItems(2))},
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, "Invalid `id` parameter, `id` must be a list of at most 2 items", err.Error())
+	assert.Nil(t, maxIds)
+}
@@ -0,0 +1,182 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// RuleFactory builds a Rule from the parameter given to it in a `validate` struct tag.
+// For the tag `validate:"rules=max:100"`, the factory registered as "max" is called with "100".
+// An error should be returned if the parameter is malformed.
+type RuleFactory func(param string) (Rule, error)
+
+// ruleFactories holds the RuleFactories registered with RegisterRule
+var ruleFactories = newRuleRegistry()
+
+// RegisterRule makes a RuleFactory available, under name, to the `rules` of every
+// `validate` struct tag.  RegisterRule is safe for concurrent use.
+func RegisterRule(name string, factory RuleFactory) {
+	defaultValidator.RegisterRule(name, factory)
+}
+
+// WithRule registers a RuleFactory with the Validator.  See (*Validator).RegisterRule.
+func WithRule(name string, factory RuleFactory) Option {
+	return func(v *Validator) {
+		v.RegisterRule(name, factory)
+	}
+}
+
+// RegisterRule makes a RuleFactory available, under name, to the `rules` of the `validate`
+// struct tags handled by this Validator only.  Rules registered with the Validator take
+// precedence over ones registered with the package-level RegisterRule.
+func (v *Validator) RegisterRule(name string, factory RuleFactory) {
+	v.ruleFactories.register(name, factory)
+}
+
+// ValidateStruct validates the fields of the struct dst points to, using the default
+// Validator.  See (*Validator).ValidateStruct.
+func ValidateStruct(dst interface{}, source func(name string) []string) error {
+	return defaultValidator.ValidateStruct(dst, source)
+}
+
+// ValidateStruct builds a Value for each exported field of the struct dst points to that
+// has a `validate` tag, and validates them.  The tag is a comma separated list of options:
+//
+//	UserID int `validate:"name=user_id,default=10,rules=required|max:100"`
+//
+// name is the Name of the Value, and defaults to the name of the field.  default is the
+// Default of the Value.  rules is a | separated list of registered rules, each optionally
+// followed by a : and a parameter.  The rule required, or the option required on its own,
+// makes the Value Required, and the option optional makes it Optional.
+//
+// source is called with the name of each field, and returns its inputs, or nil if they are absent.
+func (v *Validator) ValidateStruct(dst interface{}, source func(name string) []string) error {
+	values, err := v.structValues(dst, source)
+	if err != nil {
+		return v.misconfigured(err)
+	}
+	err = v.Check(values)
+	if err != nil {
+		return err
+	}
+	return v.Validate(values)
+}
+
+// structValues builds a Value for each tagged field of the struct dst points to
+func (v *Validator) structValues(dst interface{}, source func(name string) []string) ([]*Value, error) {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return nil, &UnsupportedTypeError{Name: "dst", Type: reflect.TypeOf(dst)}
+	}
+	rv = rv.Elem()
+
+	var values []*Value
+	for i := 0; i < rv.NumField(); i++ {
+		field := rv.Type().Field(i)
+		tag, ok := field.Tag.Lookup("validate")
+		if !ok || tag == "-" || field.PkgPath != "" {
+			continue
+		}
+
+		// Build the Value from the tag
+		value := &Value{Result: rv.Field(i).Addr().Interface(), Name: field.Name}
+		err := v.parseTag(tag, value)
+		if err != nil {
+			return nil, &TagError{Field: field.Name, Tag: tag, Err: err}
+		}
+
+		setInputs(value, source(value.Name))
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// parseTag sets the options of a `validate` tag on a Value
+func (v *Validator) parseTag(tag string, value *Value) error {
+	for _, option := range strings.Split(tag, ",") {
+		switch option {
+		case "":
+			continue
+		case "required":
+			value.Required = true
+			continue
+		case "optional":
+			value.Optional = true
+			continue
+		}
+		parts := strings.SplitN(option, "=", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("option %q must be of the form key=value", option)
+		}
+		switch parts[0] {
+		case "name":
+			value.Name = parts[1]
+		case "default":
+			value.Default = parts[1]
+		case "rules":
+			for _, rule := range strings.Split(parts[1], "|") {
+				if rule == "required" {
+					value.Required = true
+					continue
+				}
+				built, err := v.buildRule(rule)
+				if err != nil {
+					return err
+				}
+				value.Rules = append(value.Rules, built)
+			}
+		default:
+			return fmt.Errorf("unknown option %q", parts[0])
+		}
+	}
+	return nil
+}
+
+// buildRule builds a Rule from its name and optional parameter, such as "max:100"
+func (v *Validator) buildRule(rule string) (Rule, error) {
+	name, param := rule, ""
+	if i := strings.Index(rule, ":"); i >= 0 {
+		name, param = rule[:i], rule[i+1:]
+	}
+	factory, ok := v.ruleFactories.lookup(name)
+	if !ok {
+		factory, ok = ruleFactories.lookup(name)
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown rule %q", name)
+	}
+	built, err := factory(param)
+	if err != nil {
+		return nil, fmt.Errorf("rule %q: %v", name, err)
+	}
+	return built, nil
+}
+
+// ruleRegistry is a concurrency safe map of names to RuleFactories
+type ruleRegistry struct {
+	mu        sync.RWMutex
+	factories map[string]RuleFactory
+}
+
+func newRuleRegistry() *ruleRegistry {
+	return &ruleRegistry{factories: map[string]RuleFactory{}}
+}
+
+func (r *ruleRegistry) register(name string, factory RuleFactory) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if factory == nil {
+		delete(r.factories, name)
+		return
+	}
+	r.factories[name] = factory
+}
+
+func (r *ruleRegistry) lookup(name string) (RuleFactory, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	factory, ok := r.factories[name]
+	return factory, ok
+}
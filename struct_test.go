@@ -0,0 +1,176 @@
+package validator_test
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	v "github.com/go-carrot/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+// maxRule is a RuleFactory for MaxVal, used to test struct tags
+func maxRule(param string) (v.Rule, error) {
+	maxValue, err := strconv.Atoi(param)
+	if err != nil {
+		return nil, err
+	}
+	return MaxVal(maxValue), nil
+}
+
+// mapSource creates a ValidateStruct source from a map
+func mapSource(inputs map[string][]string) func(name string) []string {
+	return func(name string) []string {
+		return inputs[name]
+	}
+}
+
+// listUsersRequest is a struct used to test ValidateStruct
+type listUsersRequest struct {
+	UserID  int      `validate:"name=user_id,rules=required|max:100"`
+	Limit   int      `validate:"name=limit,default=10"`
+	Tags    []string `validate:"name=tag"`
+	Query   string   `validate:""`
+	Ignored string
+	Skipped string `validate:"-"`
+}
+
+// TestValidateStruct tests building values from struct tags
+func TestValidateStruct(t *testing.T) {
+	v.RegisterRule("max", maxRule)
+	defer v.RegisterRule("max", nil)
+
+	// Test success case
+	var request listUsersRequest
+	err := v.ValidateStruct(&request, mapSource(map[string][]string{
+		"user_id": {"42"},
+		"tag":     {"a", "b"},
+		"Query":   {"hello"},
+		"Ignored": {"ignored"},
+		"Skipped": {"skipped"},
+	}))
+	assert.Nil(t, err)
+	assert.Equal(t, 42, request.UserID)
+	assert.Equal(t, 10, request.Limit)
+	assert.Equal(t, []string{"a", "b"}, request.Tags)
+	assert.Equal(t, "hello", request.Query)
+	assert.Equal(t, "", request.Ignored)
+	assert.Equal(t, "", request.Skipped)
+
+	// Test required case
+	var missingRequest listUsersRequest
+	err = v.ValidateStruct(&missingRequest, mapSource(map[string][]string{}))
+	assert.NotNil(t, err)
+	assert.Equal(t, "Missing `user_id` parameter", err.Error())
+
+	// Test rule failure case
+	var maxRequest listUsersRequest
+	err = v.ValidateStruct(&maxRequest, mapSource(map[string][]string{
+		"user_id": {"101"},
+	}))
+	assert.NotNil(t, err)
+	assert.Equal(t, "The value of user_id may not be greater than 100", err.Error())
+
+	// Test type mismatch case
+	var typeRequest listUsersRequest
+	err = v.ValidateStruct(&typeRequest, mapSource(map[string][]string{
+		"user_id": {"42"},
+		"limit":   {"ten"},
+	}))
+	assert.NotNil(t, err)
+	assert.Equal(t, "Invalid `limit` parameter, `limit` must be an int", err.Error())
+}
+
+// TestValidateStructTagErrors tests that malformed tags are reported as a TagError
+func TestValidateStructTagErrors(t *testing.T) {
+	source := mapSource(map[string][]string{})
+
+	// Test unknown rule
+	var unknownRule struct {
+		ID int `validate:"rules=unknown"`
+	}
+	err := v.ValidateStruct(&unknownRule, source)
+	var tagErr *v.TagError
+	assert.True(t, errors.As(err, &tagErr))
+	assert.Equal(t, "ID", tagErr.Field)
+
+	// Test unknown option
+	var unknownOption struct {
+		ID int `validate:"size=10"`
+	}
+	err = v.ValidateStruct(&unknownOption, source)
+	assert.True(t, errors.As(err, &tagErr))
+
+	// Test malformed rule parameter
+	var malformed struct {
+		ID int `validate:"rules=max:ten"`
+	}
+	err = v.New(v.WithRule("max", maxRule)).ValidateStruct(&malformed, source)
+	assert.True(t, errors.As(err, &tagErr))
+
+	// Test non struct
+	var id int
+	err = v.ValidateStruct(&id, source)
+	assert.True(t, errors.Is(err, v.ErrUnsupportedType))
+}
+
+// TestValidatorRule tests that a rule registered with a Validator is only used by that Validator
+func TestValidatorRule(t *testing.T) {
+	validator := v.New(v.WithRule("even", func(param string) (v.Rule, error) {
+		return func(name string, input string) error {
+			if n, _ := strconv.Atoi(input); n%2 != 0 {
+				return fmt.Errorf("%v must be even", name)
+			}
+			return nil
+		}, nil
+	}))
+	var request struct {
+		Count int `validate:"name=count,rules=even"`
+	}
+
+	// Test success case
+	err := validator.ValidateStruct(&request, mapSource(map[string][]string{"count": {"4"}}))
+	assert.Nil(t, err)
+	assert.Equal(t, 4, request.Count)
+
+	// Test failure case
+	err = validator.ValidateStruct(&request, mapSource(map[string][]string{"count": {"3"}}))
+	assert.Equal(t, "count must be even", err.Error())
+
+	// Test the default Validator doesn't know about the rule
+	err = v.ValidateStruct(&request, mapSource(map[string][]string{"count": {"4"}}))
+	var tagErr *v.TagError
+	assert.True(t, errors.As(err, &tagErr))
+}
+
+// TestValidateStructInvalidDefault tests that a default in a tag that doesn't fit its
+// field is reported as a misconfiguration, even when the input is present
+func TestValidateStructInvalidDefault(t *testing.T) {
+	var request struct {
+		Limit int `validate:"name=limit,default=ten"`
+	}
+	err := v.ValidateStruct(&request, mapSource(map[string][]string{"limit": {"10"}}))
+	assert.True(t, errors.Is(err, v.ErrInvalidDefault))
+	assert.Equal(t, 0, request.Limit)
+}
+
+// TestValidateStructFlags tests the required and optional tag options
+func TestValidateStructFlags(t *testing.T) {
+	request := struct {
+		ID    int `validate:"name=id,required"`
+		Limit int `validate:"name=limit,optional"`
+	}{Limit: 5}
+
+	// Test success case
+	err := v.ValidateStruct(&request, mapSource(map[string][]string{"id": {"1"}}))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, request.ID)
+	assert.Equal(t, 5, request.Limit)
+
+	// Test missing case
+	err = v.ValidateStruct(&request, mapSource(map[string][]string{}))
+	var fieldErr *v.FieldError
+	assert.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, v.CodeMissing, fieldErr.Code)
+}
@@ -0,0 +1,262 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Date is a calendar date without a time or a time zone, such as a birthday.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// ParseDate parses a date in the layout 2006-01-02.
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return Date{}, err
+	}
+	return Date{Year: t.Year(), Month: t.Month(), Day: t.Day()}, nil
+}
+
+// String returns the date in the layout 2006-01-02.
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// In returns the time.Time of midnight at the start of the date in location.
+func (d Date) In(location *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, location)
+}
+
+// Period is an ISO 8601 duration, such as P1DT2H.  Unlike a time.Duration, it can hold
+// calendar units, whose length depends on the date they are added to.
+type Period struct {
+	Years  int
+	Months int
+	Weeks  int
+	Days   int
+
+	// Time holds the hours, minutes and seconds of the period
+	Time time.Duration
+}
+
+// periodPattern matches an ISO 8601 duration in the format PnYnMnWnDTnHnMnS
+var periodPattern = regexp.MustCompile(`^P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:[.,]\d+)?)S)?)?$`)
+
+// ParsePeriod parses an ISO 8601 duration in the format PnYnMnWnDTnHnMnS, such as P1DT2H.
+// Seconds may have a fraction, and units that are zero may be left out.
+func ParsePeriod(s string) (Period, error) {
+	match := periodPattern.FindStringSubmatch(s)
+	if match == nil || s == "P" || strings.HasSuffix(s, "T") {
+		return Period{}, fmt.Errorf("invalid ISO 8601 duration %q", s)
+	}
+
+	// Parse the date units
+	var period Period
+	for i, unit := range []*int{&period.Years, &period.Months, &period.Weeks, &period.Days} {
+		if match[i+1] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(match[i+1])
+		if errors.Is(err, strconv.ErrRange) {
+			return Period{}, &periodRangeError{input: s, date: true}
+		}
+		if err != nil {
+			return Period{}, err
+		}
+		*unit = n
+	}
+
+	// Parse the time units
+	for i, unit := range []time.Duration{time.Hour, time.Minute} {
+		if match[i+5] == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(match[i+5], 10, 64)
+		if err != nil {
+			return Period{}, err
+		}
+		d, ok := scaleDuration(n, unit)
+		if !ok || period.Time > math.MaxInt64-d {
+			return Period{}, &periodRangeError{input: s}
+		}
+		period.Time += d
+	}
+	if match[7] != "" {
+		seconds, err := strconv.ParseFloat(strings.Replace(match[7], ",", ".", 1), 64)
+		if err != nil {
+			return Period{}, err
+		}
+		if seconds >= math.MaxInt64/float64(time.Second) {
+			return Period{}, &periodRangeError{input: s}
+		}
+		d := time.Duration(seconds * float64(time.Second))
+		if period.Time > math.MaxInt64-d {
+			return Period{}, &periodRangeError{input: s}
+		}
+		period.Time += d
+	}
+	return period, nil
+}
+
+// periodRangeError is returned by ParsePeriod when the years, months, weeks or days of a
+// period don't fit in an int, or its hours, minutes and seconds don't fit in a time.Duration.
+// It matches strconv.ErrRange with errors.Is.
+type periodRangeError struct {
+	input string
+
+	// date is set if a date unit is out of range, rather than the time units
+	date bool
+}
+
+// Error describes which units are out of range.
+func (err *periodRangeError) Error() string {
+	if err.date {
+		return fmt.Sprintf("ISO 8601 duration %q has a date unit out of range", err.input)
+	}
+	return fmt.Sprintf("ISO 8601 duration %q has hours, minutes and seconds out of range", err.input)
+}
+
+// Is reports whether target is strconv.ErrRange.
+func (err *periodRangeError) Is(target error) bool {
+	return target == strconv.ErrRange
+}
+
+// scaleDuration multiplies n by unit, reporting false if the result doesn't fit in a time.Duration
+func scaleDuration(n int64, unit time.Duration) (time.Duration, bool) {
+	if n > math.MaxInt64/int64(unit) || n < math.MinInt64/int64(unit) {
+		return 0, false
+	}
+	return time.Duration(n) * unit, true
+}
+
+// AddTo adds the period to t, with the calendar units added by time.Time.AddDate.
+func (p Period) AddTo(t time.Time) time.Time {
+	return t.AddDate(p.Years, p.Months, p.Weeks*7+p.Days).Add(p.Time)
+}
+
+// String returns the period as an ISO 8601 duration.
+func (p Period) String() string {
+	var b strings.Builder
+	b.WriteString("P")
+	for _, unit := range []struct {
+		n      int
+		suffix string
+	}{{p.Years, "Y"}, {p.Months, "M"}, {p.Weeks, "W"}, {p.Days, "D"}} {
+		if unit.n != 0 {
+			fmt.Fprintf(&b, "%d%s", unit.n, unit.suffix)
+		}
+	}
+	if p.Time != 0 {
+		b.WriteString("T")
+		hours := p.Time / time.Hour
+		minutes := (p.Time % time.Hour) / time.Minute
+		seconds := p.Time % time.Minute
+		if hours != 0 {
+			fmt.Fprintf(&b, "%dH", hours)
+		}
+		if minutes != 0 {
+			fmt.Fprintf(&b, "%dM", minutes)
+		}
+		if seconds != 0 {
+			b.WriteString(strconv.FormatFloat(seconds.Seconds(), 'f', -1, 64) + "S")
+		}
+	}
+	if b.Len() == 1 {
+		return "PT0S"
+	}
+	return b.String()
+}
+
+func durationHandler(input string, value *Value) error {
+	if skipEmpty(input, value) {
+		return nil
+	}
+	res, err := parseDuration(input, value)
+	if err != nil {
+		return err
+	}
+	*value.Result.(*time.Duration) = res
+	return nil
+}
+
+// parseDuration parses a time.Duration such as "30s" or "5m", or a plain integer if the
+// Value or its Validator has a duration unit
+func parseDuration(input string, value *Value) (time.Duration, error) {
+	unit := value.DurationUnit
+	if unit == 0 {
+		unit = value.validatorOrDefault().durationUnit
+	}
+	if unit != 0 {
+		if n, err := strconv.ParseInt(input, 10, 64); err == nil {
+			res, ok := scaleDuration(n, unit)
+			if !ok {
+				min, max := math.MinInt64/int64(unit), math.MaxInt64/int64(unit)
+				return 0, outOfRange(value, input, fmt.Sprintf("between %v and %v %v", min, max, unitName(unit)))
+			}
+			return res, nil
+		}
+	}
+	res, err := time.ParseDuration(input)
+	if err != nil {
+		if unit != 0 {
+			return 0, invalidParam(value, input, fmt.Sprintf("a duration (such as 30s or 5m) or a whole number of %v", unitName(unit)))
+		}
+		return 0, invalidParam(value, input, "a duration (such as 30s or 5m)")
+	}
+	return res, nil
+}
+
+// unitName describes a duration unit, such as "seconds" for time.Second
+func unitName(unit time.Duration) string {
+	switch unit {
+	case time.Millisecond:
+		return "milliseconds"
+	case time.Second:
+		return "seconds"
+	case time.Minute:
+		return "minutes"
+	case time.Hour:
+		return "hours"
+	}
+	return "multiples of " + unit.String()
+}
+
+func dateHandler(input string, value *Value) error {
+	if skipEmpty(input, value) {
+		return nil
+	}
+	res, err := ParseDate(input)
+	if err != nil {
+		return invalidParam(value, input, "a date (2006-01-02)")
+	}
+	*value.Result.(*Date) = res
+	return nil
+}
+
+func periodHandler(input string, value *Value) error {
+	if skipEmpty(input, value) {
+		return nil
+	}
+	res, err := ParsePeriod(input)
+	var rangeErr *periodRangeError
+	if errors.As(err, &rangeErr) && rangeErr.date {
+		return outOfRange(value, input, fmt.Sprintf("an ISO 8601 duration of at most %v years, months, weeks and days", math.MaxInt))
+	}
+	if rangeErr != nil {
+		return outOfRange(value, input, fmt.Sprintf("an ISO 8601 duration of at most %v in hours, minutes and seconds", time.Duration(math.MaxInt64)))
+	}
+	if err != nil {
+		return invalidParam(value, input, "an ISO 8601 duration (such as P1DT2H)")
+	}
+	*value.Result.(*Period) = res
+	return nil
+}
@@ -0,0 +1,159 @@
+package validator_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	v "github.com/go-carrot/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDuration tests handling of a time.Duration as the result
+func TestDuration(t *testing.T) {
+	// Test success case
+	var timeout time.Duration
+	err := v.Validate([]*v.Value{
+		{Result: &timeout, Name: "timeout", Input: "1m30s"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 90*time.Second, timeout)
+
+	// Test plain integer case, without a unit
+	var integerTimeout time.Duration
+	err = v.Validate([]*v.Value{
+		{Result: &integerTimeout, Name: "timeout", Input: "30"},
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, "Invalid `timeout` parameter, `timeout` must be a duration (such as 30s or 5m)", err.Error())
+
+	// Test plain integer case, with a unit
+	var unitTimeout time.Duration
+	err = v.Validate([]*v.Value{
+		{Result: &unitTimeout, Name: "timeout", Input: "30", DurationUnit: time.Second},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 30*time.Second, unitTimeout)
+
+	// Test plain integer case, with the unit of a Validator
+	var validatorTimeout time.Duration
+	err = v.New(v.WithDurationUnit(time.Millisecond)).Validate([]*v.Value{
+		{Result: &validatorTimeout, Name: "timeout", Input: "250"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 250*time.Millisecond, validatorTimeout)
+
+	// Test error case
+	var errorTimeout time.Duration
+	err = v.Validate([]*v.Value{
+		{Result: &errorTimeout, Name: "timeout", Input: "abc", DurationUnit: time.Second},
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, "Invalid `timeout` parameter, `timeout` must be a duration (such as 30s or 5m) or a whole number of seconds", err.Error())
+
+	// Test overflow cases
+	for _, input := range []string{"10000000000", "-10000000000"} {
+		var overflowTimeout time.Duration
+		err = v.Validate([]*v.Value{
+			{Result: &overflowTimeout, Name: "timeout", Input: input, DurationUnit: time.Second},
+		})
+		var fieldErr *v.FieldError
+		assert.True(t, errors.As(err, &fieldErr), input)
+		assert.Equal(t, v.CodeOutOfRange, fieldErr.Code, input)
+		assert.Equal(t, "Invalid `timeout` parameter, `timeout` must be between -9223372036 and 9223372036 seconds", err.Error(), input)
+		assert.Equal(t, time.Duration(0), overflowTimeout, input)
+	}
+}
+
+// TestDate tests handling of a Date as the result
+func TestDate(t *testing.T) {
+	// Test success case
+	var birthday v.Date
+	err := v.Validate([]*v.Value{
+		{Result: &birthday, Name: "birthday", Input: "1990-02-28"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, v.Date{Year: 1990, Month: time.February, Day: 28}, birthday)
+	assert.Equal(t, "1990-02-28", birthday.String())
+	assert.Equal(t, time.Date(1990, time.February, 28, 0, 0, 0, 0, time.UTC), birthday.In(time.UTC))
+
+	// Test error cases
+	for _, input := range []string{"", "1990-02-30", "1990-02-28T00:00:00Z", "abcd"} {
+		var errorBirthday v.Date
+		err = v.Validate([]*v.Value{
+			{Result: &errorBirthday, Name: "birthday", Input: input},
+		})
+		assert.NotNil(t, err, input)
+		assert.Equal(t, v.Date{}, errorBirthday)
+	}
+}
+
+// TestPeriod tests handling of a Period as the result
+func TestPeriod(t *testing.T) {
+	// Test success cases
+	cases := map[string]v.Period{
+		"P1DT2H":         {Days: 1, Time: 2 * time.Hour},
+		"P1Y2M3W4D":      {Years: 1, Months: 2, Weeks: 3, Days: 4},
+		"PT5M30.5S":      {Time: 5*time.Minute + 30500*time.Millisecond},
+		"P1YT1H1M1S":     {Years: 1, Time: time.Hour + time.Minute + time.Second},
+		"PT0,5S":         {Time: 500 * time.Millisecond},
+		"P0D":            {},
+		"P10Y11M12DT13H": {Years: 10, Months: 11, Days: 12, Time: 13 * time.Hour},
+	}
+	for input, expected := range cases {
+		var period v.Period
+		err := v.Validate([]*v.Value{
+			{Result: &period, Name: "period", Input: input},
+		})
+		assert.Nil(t, err, input)
+		assert.Equal(t, expected, period, input)
+	}
+
+	// Test error cases
+	for _, input := range []string{"", "P", "PT", "P1H", "1D", "P1DT", "P-1D", "PT1.5H"} {
+		var period v.Period
+		err := v.Validate([]*v.Value{
+			{Result: &period, Name: "period", Input: input},
+		})
+		assert.NotNil(t, err, input)
+	}
+	// Test overflow cases
+	for _, input := range []string{"PT9999999999H", "PT2562047H999999M", "PT9999999999999S", "PT2562047H60M"} {
+		var period v.Period
+		err := v.Validate([]*v.Value{
+			{Result: &period, Name: "period", Input: input},
+		})
+		var fieldErr *v.FieldError
+		assert.True(t, errors.As(err, &fieldErr), input)
+		assert.Equal(t, v.CodeOutOfRange, fieldErr.Code, input)
+		assert.Equal(t, v.Period{}, period, input)
+	}
+	_, err := v.ParsePeriod("PT9999999999H")
+	assert.True(t, errors.Is(err, strconv.ErrRange))
+
+	// Test date unit overflow cases, which report the range of the date units
+	for _, input := range []string{"P99999999999999999999D", "P99999999999999999999Y", "P1Y99999999999999999999MT1H"} {
+		var period v.Period
+		err := v.Validate([]*v.Value{
+			{Result: &period, Name: "period", Input: input},
+		})
+		var fieldErr *v.FieldError
+		assert.True(t, errors.As(err, &fieldErr), input)
+		assert.Equal(t, v.CodeOutOfRange, fieldErr.Code, input)
+		assert.Equal(t, "Invalid `period` parameter, `period` must be an ISO 8601 duration of at most 9223372036854775807 years, months, weeks and days", err.Error(), input)
+		assert.Equal(t, v.Period{}, period, input)
+	}
+	_, err = v.ParsePeriod("P99999999999999999999D")
+	assert.True(t, errors.Is(err, strconv.ErrRange))
+}
+
+// TestPeriodMethods tests the String and AddTo methods of a Period
+func TestPeriodMethods(t *testing.T) {
+	period := v.Period{Months: 1, Days: 1, Time: 2*time.Hour + 1500*time.Millisecond}
+	assert.Equal(t, "P1M1DT2H1.5S", period.String())
+	assert.Equal(t, "PT0S", v.Period{}.String())
+
+	start := time.Date(2020, time.January, 31, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2020, time.March, 3, 2, 0, 1, 500000000, time.UTC), period.AddTo(start))
+}
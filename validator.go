@@ -1,21 +1,52 @@
 package validator
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
 	"time"
 
 	"gopkg.in/guregu/null.v3"
+	"gopkg.in/guregu/null.v3/zero"
 )
 
 // Value is the definition of a parameter that you would like to perform validation against.
 type Value struct {
-	Result      interface{}
-	Default     string
-	Name        string
-	Input       string
-	Rules       []Rule
-	TypeHandler TypeHandler
+	Result             interface{}
+	Default            string
+	DefaultValue       interface{}
+	Name               string
+	Required           bool
+	Optional           bool
+	Input              string
+	Inputs             []string
+	Rules              []Rule
+	StateRules         []StateRule
+	TypeHandler        TypeHandler
+	ContextRules       []ContextRule
+	ContextTypeHandler ContextTypeHandler
+	PostRules          []PostRule
+	Base               int
+	NoExponent         bool
+	Checkbox           bool
+	TimeLayouts        []string
+	Location           *time.Location
+	DurationUnit       time.Duration
+	Source             Source
+	Pointer            string
+	State              InputState
+	JSONKind           JSONKind
+	Raw                json.RawMessage
+
+	// validator is the Validator currently validating the Value
+	validator *Validator
+
+	// supplied records whether the last validation parsed a supplied input into the Result,
+	// and skipped whether a TypeHandler skipped an empty input
+	supplied bool
+	skipped  bool
 }
 
 // TypeHandler is a function that is responsible for
@@ -28,86 +59,539 @@ type TypeHandler func(input string, value *Value) error
 // values as they were set in the Value struct.
 type Rule func(name string, input string) error
 
-// Validate checks if an array of values passes their specified rules
+// StateRule is a Rule that also receives the state of the input, so it can tell an absent
+// input apart from one that is present but empty.  state is InputAbsent when the input is
+// the Default, and never InputUnset.
+type StateRule func(name string, input string, state InputState) error
+
+// ContextTypeHandler is a TypeHandler that receives the context.Context of the validation,
+// for handlers that call out to a database or another service.
+type ContextTypeHandler func(ctx context.Context, input string, value *Value) error
+
+// ContextRule is a Rule that receives the context.Context of the validation, for rules
+// that call out to a database or another service, such as checking a username isn't taken.
+type ContextRule func(ctx context.Context, name string, input string) error
+
+// Strict makes every Validator panic, instead of returning an error, when a Value is
+// misconfigured (for example a Result of an unsupported type).  This is useful in
+// development, where a misconfigured Value is a bug that should fail loudly.
+var Strict = false
+
+// Validator validates Values using its own TypeHandler registry and options, so that
+// separate parts of an application can be configured independently.
+// A Validator is safe for concurrent use.
+type Validator struct {
+	typeHandlers  *registry
+	ruleFactories *ruleRegistry
+	formatter     ErrorFormatter
+	strict        bool
+	timeLayouts   []string
+	location      *time.Location
+	durationUnit  time.Duration
+	base          int
+
+	// allowNonFinite allows NaN and infinite floats, and noExponent disallows exponents in floats
+	allowNonFinite bool
+	noExponent     bool
+	boolWords      *boolWords
+}
+
+// boolWords are the words a Validator accepts for bool inputs
+type boolWords struct {
+	truthy []string
+	falsy  []string
+}
+
+// Option configures a Validator created with New.
+type Option func(*Validator)
+
+// ErrorFormatter builds the message of a FieldError returned from a Validator.
+type ErrorFormatter func(err *FieldError) string
+
+// WithStrict makes the Validator panic, instead of returning an error, when a Value
+// is misconfigured.  See Strict.
+func WithStrict() Option {
+	return func(v *Validator) {
+		v.strict = true
+	}
+}
+
+// WithErrorFormatter sets the function used to build the message of every FieldError
+// the Validator returns.
+func WithErrorFormatter(formatter ErrorFormatter) Option {
+	return func(v *Validator) {
+		v.formatter = formatter
+	}
+}
+
+// WithTypeHandler registers a TypeHandler with the Validator.  See (*Validator).RegisterTypeHandler.
+func WithTypeHandler(t reflect.Type, handler TypeHandler) Option {
+	return func(v *Validator) {
+		v.RegisterTypeHandler(t, handler)
+	}
+}
+
+// WithBase sets the base integer inputs are parsed in, for Values without a Base of their
+// own.  This may be from 2 to 36, or BasePrefixed to follow the prefix rules of Go
+// integer literals, and defaults to 10.
+func WithBase(base int) Option {
+	return func(v *Validator) {
+		v.base = base
+	}
+}
+
+// WithNonFiniteFloats allows float inputs of NaN and infinity, such as "NaN", "Inf" and
+// "-Infinity", which a Validator created with New rejects by default.  The package-level
+// functions allow them, for compatibility.
+func WithNonFiniteFloats() Option {
+	return func(v *Validator) {
+		v.allowNonFinite = true
+	}
+}
+
+// WithNoExponent rejects float inputs in exponent notation, such as "1e3", for every Value.
+// This is useful when every float is a money-like amount.  See Value.NoExponent.
+func WithNoExponent() Option {
+	return func(v *Validator) {
+		v.noExponent = true
+	}
+}
+
+// WithBoolWords sets the words accepted for true and false bool inputs, which are matched
+// case insensitively, in place of the ones accepted by strconv.ParseBool.  For example:
+//
+//	validator.WithBoolWords([]string{"true", "yes", "y", "on", "1"}, []string{"false", "no", "n", "off", "0"})
+func WithBoolWords(truthy []string, falsy []string) Option {
+	return func(v *Validator) {
+		v.boolWords = &boolWords{truthy: truthy, falsy: falsy}
+	}
+}
+
+// WithTimeLayouts sets the layouts, tried in order, that time inputs may be in.  This is
+// used by Values without TimeLayouts of their own, and defaults to time.RFC3339.  As well as
+// the layouts of the time package, LayoutUnix and LayoutUnixMilli may be used.
+func WithTimeLayouts(layouts ...string) Option {
+	return func(v *Validator) {
+		v.timeLayouts = layouts
+	}
+}
+
+// WithLocation sets the time zone of time inputs in layouts without an offset.  This is
+// used by Values without a Location of their own, and defaults to UTC.
+func WithLocation(location *time.Location) Option {
+	return func(v *Validator) {
+		v.location = location
+	}
+}
+
+// WithDurationUnit allows time.Duration inputs to be a plain integer, counting unit, such
+// as time.Second.  This is used by Values without a DurationUnit of their own.
+func WithDurationUnit(unit time.Duration) Option {
+	return func(v *Validator) {
+		v.durationUnit = unit
+	}
+}
+
+// New creates a Validator configured with opts.
+func New(opts ...Option) *Validator {
+	v := &Validator{typeHandlers: newRegistry(), ruleFactories: newRuleRegistry()}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
+}
+
+// defaultValidator is the Validator used by the package-level functions
+var defaultValidator = &Validator{typeHandlers: typeHandlers, ruleFactories: ruleFactories, allowNonFinite: true}
+
+// Validate checks if an array of values passes their specified rules, using
+// the default Validator.  See (*Validator).Validate.
 func Validate(values []*Value) error {
-	// Going through all values
+	return defaultValidator.Validate(values)
+}
+
+// ValidateContext checks if an array of values passes their specified rules, using
+// the default Validator.  See (*Validator).ValidateContext.
+func ValidateContext(ctx context.Context, values []*Value) error {
+	return defaultValidator.ValidateContext(ctx, values)
+}
+
+// ValidateAll checks every Value in the array, using the default Validator.
+// See (*Validator).ValidateAll.
+func ValidateAll(values []*Value) error {
+	return defaultValidator.ValidateAll(values)
+}
+
+// RegisterTypeHandler makes handler the TypeHandler for every Value with a Result of
+// type *T, where T is the type t, for this Validator only.  Handlers registered with
+// the Validator take precedence over ones registered with the package-level
+// RegisterTypeHandler, which take precedence over the built-in ones.
+func (v *Validator) RegisterTypeHandler(t reflect.Type, handler TypeHandler) {
+	v.typeHandlers.register(t, handler)
+}
+
+// Validate checks if an array of values passes their specified rules.
+// Validation stops at the first Value that fails, and that error is returned.
+func (v *Validator) Validate(values []*Value) error {
+	return v.ValidateContext(context.Background(), values)
+}
+
+// ValidateContext checks if an array of values passes their specified rules, passing ctx to
+// their ContextRules and ContextTypeHandler.  Validation stops at the first Value that fails,
+// and that error is returned.  If ctx is done, validation stops and ctx.Err() is returned.
+func (v *Validator) ValidateContext(ctx context.Context, values []*Value) error {
 	for _, value := range values {
-		// Setting default, if value string isn't set
-		resolvedInput := value.Input
-		if resolvedInput == "" {
-			resolvedInput = value.Default
+		err := v.validateValue(ctx, value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateAll checks every Value in the array, rather than stopping at the first
+// failure.  If any Value fails, an Errors is returned holding the first failure
+// of each failing Value, in order.
+func (v *Validator) ValidateAll(values []*Value) error {
+	var errs Errors
+	for _, value := range values {
+		err := v.validateValue(context.Background(), value)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// Check reports misconfigured Values without validating their inputs, using the default
+// Validator.  See (*Validator).Check.
+func Check(values []*Value) error {
+	return defaultValidator.Check(values)
+}
+
+// Check reports misconfigured Values without validating their inputs, so that mistakes are
+// caught where the Values are built, such as at start up or in a test, rather than when a
+// request is validated.  An UnsupportedTypeError is returned for a Result that can't be
+// handled, and an InvalidDefaultError for a Default or DefaultValue that doesn't fit the Result.
+func (v *Validator) Check(values []*Value) error {
+	for _, value := range values {
+		err := v.checkValue(value)
+		if err != nil {
+			return v.misconfigured(err)
+		}
+	}
+	return nil
+}
+
+// checkValue checks that a Value has a TypeHandler, that its defaults fit its Result, and
+// that its Base is valid
+func (v *Validator) checkValue(value *Value) error {
+	err := checkDefaultValue(value)
+	if err != nil {
+		return err
+	}
+	err = v.checkBase(value)
+	if err != nil {
+		return err
+	}
+	handler, err := v.handlerFor(context.Background(), value)
+	if err != nil {
+		return err
+	}
+
+	// Parse the Default into a scratch Result, which needs a Result that points somewhere
+	if value.Default != "" {
+		resultType := reflect.TypeOf(value.Result)
+		if resultType == nil || resultType.Kind() != reflect.Ptr || reflect.ValueOf(value.Result).IsNil() {
+			return &UnsupportedTypeError{Name: value.Name, Type: resultType}
+		}
+		scratch := *value
+		scratch.Result = reflect.New(resultType.Elem()).Interface()
+		scratch.Input = value.Default
+		scratch.Inputs = nil
+		scratch.State = InputPresent
+		scratch.validator = v
+		err := handler(value.Default, &scratch)
+		if err != nil {
+			return &InvalidDefaultError{Name: value.Name, Err: err}
 		}
+	}
+	return nil
+}
+
+// checkDefaultValue checks that the DefaultValue of a Value can be assigned to its Result
+func checkDefaultValue(value *Value) error {
+	if value.DefaultValue == nil {
+		return nil
+	}
+	resultType := reflect.TypeOf(value.Result)
+	if resultType == nil || resultType.Kind() != reflect.Ptr || reflect.ValueOf(value.Result).IsNil() {
+		return &UnsupportedTypeError{Name: value.Name, Type: resultType}
+	}
+	if value.Default != "" {
+		return &InvalidDefaultError{Name: value.Name, Err: errors.New("only one of Default and DefaultValue may be set")}
+	}
+	if defaultType := reflect.TypeOf(value.DefaultValue); !defaultType.AssignableTo(resultType.Elem()) {
+		return &InvalidDefaultError{Name: value.Name, Err: fmt.Errorf("a DefaultValue of type %v can't be assigned to a Result of type %v", defaultType, resultType)}
+	}
+	return nil
+}
 
-		// Going through all rules for each value
-		for _, rule := range value.Rules {
+// validateValue runs the rules and the type handler of a single Value
+func (v *Validator) validateValue(ctx context.Context, value *Value) error {
+	err := ctx.Err()
+	if err != nil {
+		return err
+	}
+	value.validator = v
+	value.supplied = false
+	value.skipped = false
+	err = checkDefaultValue(value)
+	if err == nil {
+		err = v.checkBase(value)
+	}
+	if err != nil {
+		return v.misconfigured(err)
+	}
+
+	// Checking required values were supplied, and skipping optional ones that weren't, except
+	// for checkboxes where absence means false
+	presence := value.Presence()
+	if value.Required && (presence == InputAbsent || presence == InputNull) {
+		return v.format(&FieldError{Name: value.Name, Code: CodeMissing})
+	}
+	absent := presence == InputAbsent
+	if absent && value.Optional && !value.Checkbox && value.Default == "" && value.DefaultValue == nil {
+		return nil
+	}
+
+	// Assigning the typed default, if the input is absent
+	if absent && value.DefaultValue != nil {
+		reflect.ValueOf(value.Result).Elem().Set(reflect.ValueOf(value.DefaultValue))
+		return nil
+	}
+
+	// Resolving input, falling back to the first of Inputs, and to the default if it is absent
+	resolvedInput := value.Input
+	if resolvedInput == "" && len(value.Inputs) > 0 {
+		resolvedInput = value.Inputs[0]
+	}
+	if absent {
+		resolvedInput = value.Default
+	}
+
+	// Going through all rules for the value, against each of Inputs if there are multiple
+	ruleInputs := value.Inputs
+	if len(ruleInputs) == 0 {
+		ruleInputs = []string{resolvedInput}
+	}
+	for _, rule := range value.Rules {
+		for _, ruleInput := range ruleInputs {
 			// Verifying rule passes
-			err := rule(value.Name, resolvedInput)
+			err := rule(value.Name, ruleInput)
 			if err != nil {
-				return err
+				return v.format(ruleFailed(value, ruleInput, err))
 			}
 		}
-
-		// Set primitive + null type handlers
-		if value.TypeHandler == nil {
-			err := applyTypeHandler(value)
+	}
+	for _, rule := range value.StateRules {
+		for _, ruleInput := range ruleInputs {
+			err := rule(value.Name, ruleInput, presence)
 			if err != nil {
-				panic(err.Error())
+				return v.format(ruleFailed(value, ruleInput, err))
 			}
 		}
-
-		// Validate against type
-		err := value.TypeHandler(resolvedInput, value)
-		if err != nil {
-			return err
+	}
+	for _, rule := range value.ContextRules {
+		for _, ruleInput := range ruleInputs {
+			// Stopping if the context is done, before verifying rule passes
+			err := ctx.Err()
+			if err != nil {
+				return err
+			}
+			err = rule(ctx, value.Name, ruleInput)
+			if err != nil {
+				return v.format(ruleFailed(value, ruleInput, err))
+			}
 		}
 	}
+
+	// Find the registered, primitive + null type handler, if the Value doesn't set its own
+	handler, err := v.handlerFor(ctx, value)
+	if err != nil {
+		return v.misconfigured(err)
+	}
+
+	// Validate against type, where a Default that fails is a misconfiguration rather than bad input
+	err = handler(resolvedInput, value)
+	if err != nil && absent && value.Default != "" {
+		return v.misconfigured(&InvalidDefaultError{Name: value.Name, Err: err})
+	}
+	if err != nil {
+		return v.format(err)
+	}
+	value.supplied = !absent && !value.skipped
+
+	// Going through all post rules for the value, unless the TypeHandler skipped the input
+	if value.skipped {
+		return nil
+	}
+	return v.runPostRules(value, resolvedInput)
+}
+
+// misconfigured panics with err if the Validator is strict, and otherwise returns it
+func (v *Validator) misconfigured(err error) error {
+	if v.strict || Strict {
+		panic(err.Error())
+	}
+	return err
+}
+
+// Presence returns whether the input of the Value was supplied.  This is its State, unless
+// that is InputUnset, in which case the input is InputPresent if Input or Inputs is set
+// and InputAbsent if not.
+func (value *Value) Presence() InputState {
+	if value.State != InputUnset {
+		return value.State
+	}
+	if value.Input != "" || len(value.Inputs) > 0 {
+		return InputPresent
+	}
+	return InputAbsent
+}
+
+// Supplied reports whether the last validation of the Value parsed a supplied input into
+// its Result.  It is false if the input was absent, if an Optional Value was skipped
+// because its input was empty, or if validation failed.
+func (value *Value) Supplied() bool {
+	return value.supplied
+}
+
+// validatorOrDefault returns the Validator validating the Value, or the default
+// Validator if a TypeHandler is called directly
+func (value *Value) validatorOrDefault() *Validator {
+	if value.validator == nil {
+		return defaultValidator
+	}
+	return value.validator
+}
+
+// format applies the ErrorFormatter of the Validator to a FieldError
+func (v *Validator) format(err error) error {
+	var fieldErr *FieldError
+	if v.formatter != nil && errors.As(err, &fieldErr) {
+		fieldErr.Message = v.formatter(fieldErr)
+	}
+	return err
+}
+
+// handlerFor finds the TypeHandler to validate a Value with: its ContextTypeHandler called
+// with ctx, its TypeHandler, or else the handler for the type of its Result.  The handler
+// isn't stored in the Value, so that another Validator can validate it with its own handlers.
+func (v *Validator) handlerFor(ctx context.Context, value *Value) (TypeHandler, error) {
+	if value.ContextTypeHandler != nil {
+		return func(input string, value *Value) error {
+			return value.ContextTypeHandler(ctx, input, value)
+		}, nil
+	}
+	if value.TypeHandler != nil {
+		return value.TypeHandler, nil
+	}
+	handler := v.typeHandlerFor(value.Result)
+	if handler == nil {
+		return nil, &UnsupportedTypeError{Name: value.Name, Type: reflect.TypeOf(value.Result)}
+	}
+	return handler, nil
+}
+
+// typeHandlerFor finds the TypeHandler for a Result.  Handlers registered with the
+// Validator are checked first, then the ones registered globally, before falling
+// back to the built-in ones, then to ones found through reflection, and finally to
+// slices and pointers of those.  nil is returned if there is no handler.
+func (v *Validator) typeHandlerFor(result interface{}) TypeHandler {
+	if handler, ok := v.typeHandlers.lookup(result); ok {
+		return handler
+	}
+	if handler, ok := typeHandlers.lookup(result); ok {
+		return handler
+	}
+	if handler := builtinTypeHandler(result); handler != nil {
+		return handler
+	}
+	if handler := fallbackTypeHandler(result); handler != nil {
+		return handler
+	}
+	if isSliceResult(result) && v.typeHandlerFor(reflect.New(reflect.TypeOf(result).Elem().Elem()).Interface()) != nil {
+		return sliceHandler
+	}
+	if isPointerResult(result) && v.typeHandlerFor(reflect.New(reflect.TypeOf(result).Elem().Elem()).Interface()) != nil {
+		return pointerHandler
+	}
 	return nil
 }
 
-func applyTypeHandler(value *Value) error {
-	switch i := (value.Result).(type) {
-	default:
-		return fmt.Errorf("go-carrot/validator cannot by default handle a Value with Result of type %v.  Must set a custom TypeHandler for %v.", reflect.TypeOf(i), value.Name)
+// builtinTypeHandler returns the built-in TypeHandler for a Result, or nil if there isn't one
+func builtinTypeHandler(result interface{}) TypeHandler {
+	switch result.(type) {
 	case *string:
-		value.TypeHandler = stringHandler
+		return stringHandler
 	case *float32:
-		value.TypeHandler = float32Handler
+		return float32Handler
 	case *float64:
-		value.TypeHandler = float64Handler
+		return float64Handler
 	case *bool:
-		value.TypeHandler = boolHandler
+		return boolHandler
 	case *int:
-		value.TypeHandler = intHandler
+		return intHandler
 	case *int8:
-		value.TypeHandler = int8Handler
+		return int8Handler
 	case *int16:
-		value.TypeHandler = int16Handler
+		return int16Handler
 	case *int32:
-		value.TypeHandler = int32Handler
+		return int32Handler
 	case *int64:
-		value.TypeHandler = int64Handler
+		return int64Handler
 	case *uint:
-		value.TypeHandler = uintHandler
+		return uintHandler
 	case *uint8:
-		value.TypeHandler = uint8Handler
+		return uint8Handler
 	case *uint16:
-		value.TypeHandler = uint16Handler
+		return uint16Handler
 	case *uint32:
-		value.TypeHandler = uint32Handler
+		return uint32Handler
 	case *uint64:
-		value.TypeHandler = uint64Handler
+		return uint64Handler
 	case *time.Time:
-		value.TypeHandler = timeHandler
+		return timeHandler
+	case *time.Duration:
+		return durationHandler
+	case *Date:
+		return dateHandler
+	case *Period:
+		return periodHandler
 	case *null.Int:
-		value.TypeHandler = nullIntHandler
+		return nullIntHandler
 	case *null.String:
-		value.TypeHandler = nullStringHandler
+		return nullStringHandler
 	case *null.Float:
-		value.TypeHandler = nullFloatHandler
+		return nullFloatHandler
 	case *null.Bool:
-		value.TypeHandler = nullBoolHandler
+		return nullBoolHandler
 	case *null.Time:
-		value.TypeHandler = nullTimeHandler
+		return nullTimeHandler
+	case *zero.Int:
+		return zeroIntHandler
+	case *zero.String:
+		return zeroStringHandler
+	case *zero.Float:
+		return zeroFloatHandler
+	case *zero.Bool:
+		return zeroBoolHandler
+	case *zero.Time:
+		return zeroTimeHandler
 	}
 	return nil
 }
@@ -1,14 +1,18 @@
 package validator_test
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
+	"net/http/httptest"
+	"reflect"
 	"strconv"
 	"testing"
 
 	v "github.com/go-carrot/validator"
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/guregu/null.v3"
 )
 
 // IsSet is a rule that makes sure the value passed in isn't an empty string
@@ -31,23 +35,35 @@ func MaxVal(maxValue int) v.Rule {
 }
 
 // TestUnknownType tests handling a Cat, which is a type that this library
-// knows nothing about (and will cause a panic)
+// knows nothing about (and will cause an error)
 func TestUnknownType(t *testing.T) {
-	defer func() {
-		if r := recover(); r != nil {
-			// Do nothing, just don't crash!
-		}
-	}()
-
 	// Testing an unknown type
 	type Cat struct{ name string }
 	var myCat Cat
-	v.Validate([]*v.Value{
+	err := v.Validate([]*v.Value{
 		{Result: &myCat, Name: "cat", Input: "{ 'name': 'rae' }"},
 	})
+	assert.NotNil(t, err)
+	assert.True(t, errors.Is(err, v.ErrUnsupportedType))
 
-	// The Validate function should panic, and we never hit this line
-	assert.Fail(t, "Line of code should be unreachable")
+	var typeErr *v.UnsupportedTypeError
+	assert.True(t, errors.As(err, &typeErr))
+	assert.Equal(t, "cat", typeErr.Name)
+	assert.Equal(t, "*validator_test.Cat", typeErr.Type.String())
+}
+
+// TestUnknownTypeStrict tests that an unknown type causes a panic in strict mode
+func TestUnknownTypeStrict(t *testing.T) {
+	v.Strict = true
+	defer func() { v.Strict = false }()
+
+	type Cat struct{ name string }
+	var myCat Cat
+	assert.Panics(t, func() {
+		v.Validate([]*v.Value{
+			{Result: &myCat, Name: "cat", Input: "{ 'name': 'rae' }"},
+		})
+	})
 }
 
 // TestCustomTypeHandler tests that we can create a new type handler
@@ -93,3 +109,451 @@ func TestCustomTypeHandler(t *testing.T) {
 	assert.Equal(t, int64(0), errorId.Int64)
 	assert.Equal(t, false, errorId.Valid)
 }
+
+// TestValidateAll tests that every value is validated, and that each
+// failure is collected in order
+func TestValidateAll(t *testing.T) {
+	// Test success case
+	var id int
+	var name string
+	err := v.ValidateAll([]*v.Value{
+		{Result: &id, Name: "id", Input: "10", Rules: []v.Rule{MaxVal(20)}},
+		{Result: &name, Name: "name", Input: "Brandon", Rules: []v.Rule{IsSet}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 10, id)
+	assert.Equal(t, "Brandon", name)
+
+	// Test failure case
+	var badId int
+	var badName string
+	var age int
+	var count int
+	err = v.ValidateAll([]*v.Value{
+		{Result: &badId, Name: "id", Input: "30", Rules: []v.Rule{MaxVal(20)}},
+		{Result: &badName, Name: "name", Input: "", Rules: []v.Rule{IsSet}},
+		{Result: &age, Name: "age", Input: "21"},
+		{Result: &count, Name: "count", Input: "abc"},
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, 21, age)
+
+	var errs v.Errors
+	assert.True(t, errors.As(err, &errs))
+	assert.Len(t, errs, 3)
+	assert.Equal(t, "The value of id may not be greater than 20", errs[0].Error())
+	assert.Equal(t, "Error, missing name", errs[1].Error())
+	assert.Equal(t, "Invalid `count` parameter, `count` must be an int", errs[2].Error())
+}
+
+// TestValidatorTypeHandler tests that a type handler registered with a Validator
+// is only used by that Validator
+func TestValidatorTypeHandler(t *testing.T) {
+	type Cat struct{ name string }
+	catHandler := func(input string, value *v.Value) error {
+		value.Result.(*Cat).name = input
+		return nil
+	}
+	validator := v.New(v.WithTypeHandler(reflect.TypeOf(Cat{}), catHandler))
+
+	// Test success case
+	var myCat Cat
+	err := validator.Validate([]*v.Value{
+		{Result: &myCat, Name: "cat", Input: "rae"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "rae", myCat.name)
+
+	// Test another Validator doesn't know about the handler
+	var otherCat Cat
+	err = v.New().Validate([]*v.Value{
+		{Result: &otherCat, Name: "cat", Input: "rae"},
+	})
+	assert.True(t, errors.Is(err, v.ErrUnsupportedType))
+
+	// Test the default Validator doesn't know about the handler
+	err = v.Validate([]*v.Value{
+		{Result: &otherCat, Name: "cat", Input: "rae"},
+	})
+	assert.True(t, errors.Is(err, v.ErrUnsupportedType))
+
+	// Test a Value reused across Validators is handled by each Validator's own handlers
+	var age null.Int
+	ageValue := &v.Value{Result: &age, Name: "age", Input: "10"}
+	err = v.Validate([]*v.Value{ageValue})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(10), age.Int64)
+	assert.Nil(t, ageValue.TypeHandler)
+	doubling := v.New(v.WithTypeHandler(reflect.TypeOf(null.Int{}), func(input string, value *v.Value) error {
+		n, _ := strconv.ParseInt(input, 10, 64)
+		*value.Result.(*null.Int) = null.IntFrom(n * 2)
+		return nil
+	}))
+	err = doubling.Validate([]*v.Value{ageValue})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(20), age.Int64)
+}
+
+// TestValidatorErrorFormatter tests that the error formatter of a Validator
+// builds the message of every FieldError it returns
+func TestValidatorErrorFormatter(t *testing.T) {
+	validator := v.New(v.WithErrorFormatter(func(err *v.FieldError) string {
+		return fmt.Sprintf("%v: %v", err.Name, err.Code)
+	}))
+
+	// Test type mismatch case
+	var id int
+	err := validator.Validate([]*v.Value{
+		{Result: &id, Name: "id", Input: "abc"},
+	})
+	assert.Equal(t, "id: type_mismatch", err.Error())
+
+	// Test rule failure case
+	var name string
+	err = validator.ValidateAll([]*v.Value{
+		{Result: &id, Name: "id", Input: "abc"},
+		{Result: &name, Name: "name", Input: "", Rules: []v.Rule{IsSet}},
+	})
+	assert.Equal(t, "id: type_mismatch; name: rule_failed", err.Error())
+}
+
+// TestValidatorStrict tests that a strict Validator panics on an unknown type
+func TestValidatorStrict(t *testing.T) {
+	type Cat struct{ name string }
+	var myCat Cat
+	assert.Panics(t, func() {
+		v.New(v.WithStrict()).Validate([]*v.Value{
+			{Result: &myCat, Name: "cat", Input: "rae"},
+		})
+	})
+}
+
+// TestPresence tests that absent, present but empty, and present inputs are told apart
+func TestPresence(t *testing.T) {
+	// Test absent case, which uses the default
+	var absent string
+	absentValue := &v.Value{Result: &absent, Name: "nickname", Default: "anonymous"}
+	err := v.Validate([]*v.Value{absentValue})
+	assert.Nil(t, err)
+	assert.Equal(t, "anonymous", absent)
+	assert.Equal(t, v.InputAbsent, absentValue.Presence())
+
+	// Test present but empty case, which doesn't use the default
+	var empty string
+	emptyValue := &v.Value{Result: &empty, Name: "nickname", Default: "anonymous", State: v.InputPresent}
+	err = v.Validate([]*v.Value{emptyValue})
+	assert.Nil(t, err)
+	assert.Equal(t, "", empty)
+	assert.Equal(t, v.InputPresent, emptyValue.Presence())
+
+	// Test present case
+	var present string
+	presentValue := &v.Value{Result: &present, Name: "nickname", Default: "anonymous", Input: "rae"}
+	err = v.Validate([]*v.Value{presentValue})
+	assert.Nil(t, err)
+	assert.Equal(t, "rae", present)
+	assert.Equal(t, v.InputPresent, presentValue.Presence())
+
+	// Test present but empty case with a rule
+	var emptyIsSet string
+	err = v.Validate([]*v.Value{
+		{Result: &emptyIsSet, Name: "nickname", Default: "anonymous", State: v.InputPresent, Rules: []v.Rule{IsSet}},
+	})
+	assert.NotNil(t, err)
+
+	// Test absent vs present but empty case with a state rule
+	var states []v.InputState
+	recordState := func(name string, input string, state v.InputState) error {
+		states = append(states, state)
+		if state == v.InputPresent && input == "" {
+			return fmt.Errorf("%v can't be cleared", name)
+		}
+		return nil
+	}
+	var stateNickname string
+	err = v.Validate([]*v.Value{
+		{Result: &stateNickname, Name: "nickname", StateRules: []v.StateRule{recordState}},
+	})
+	assert.Nil(t, err)
+	err = v.Validate([]*v.Value{
+		{Result: &stateNickname, Name: "nickname", State: v.InputPresent, StateRules: []v.StateRule{recordState}},
+	})
+	var fieldErr *v.FieldError
+	assert.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, v.CodeRuleFailed, fieldErr.Code)
+	assert.Equal(t, "nickname can't be cleared", err.Error())
+	assert.Equal(t, []v.InputState{v.InputAbsent, v.InputPresent}, states)
+}
+
+// TestPresenceFromRequest tests that FromRequest records whether each input was supplied
+func TestPresenceFromRequest(t *testing.T) {
+	r := httptest.NewRequest("GET", "/users?nickname=&name=rae", nil)
+
+	var nickname null.String
+	var name null.String
+	var missing null.String
+	var defaulted string
+	err := v.FromRequest(r, []*v.Value{
+		{Result: &nickname, Name: "nickname", Source: v.SourceQuery},
+		{Result: &name, Name: "name", Source: v.SourceQuery},
+		{Result: &missing, Name: "missing", Source: v.SourceQuery},
+		{Result: &defaulted, Name: "nickname", Default: "anonymous", Source: v.SourceQuery},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, null.StringFrom(""), nickname)
+	assert.Equal(t, null.StringFrom("rae"), name)
+	assert.False(t, missing.Valid)
+	assert.Equal(t, "", defaulted)
+}
+
+// TestDefaultValue tests typed defaults
+func TestDefaultValue(t *testing.T) {
+	// Test absent case, which uses the default
+	var limit int
+	err := v.Validate([]*v.Value{
+		{Result: &limit, Name: "limit", DefaultValue: 10},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 10, limit)
+
+	// Test present case
+	var presentLimit int
+	err = v.Validate([]*v.Value{
+		{Result: &presentLimit, Name: "limit", Input: "20", DefaultValue: 10},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 20, presentLimit)
+
+	// Test null type case
+	var name null.String
+	err = v.Validate([]*v.Value{
+		{Result: &name, Name: "name", DefaultValue: null.StringFrom("anonymous")},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, null.StringFrom("anonymous"), name)
+
+	// Test mismatched type case
+	var mismatchLimit int
+	err = v.Validate([]*v.Value{
+		{Result: &mismatchLimit, Name: "limit", Input: "20", DefaultValue: "ten"},
+	})
+	assert.True(t, errors.Is(err, v.ErrInvalidDefault))
+	assert.Equal(t, 0, mismatchLimit)
+
+	var fieldErr *v.FieldError
+	assert.False(t, errors.As(err, &fieldErr))
+
+	// Test both defaults case
+	err = v.Validate([]*v.Value{
+		{Result: &mismatchLimit, Name: "limit", Default: "10", DefaultValue: 10},
+	})
+	assert.True(t, errors.Is(err, v.ErrInvalidDefault))
+}
+
+// TestInvalidDefault tests that a Default that can't be parsed is reported as a
+// misconfiguration, rather than as bad input
+func TestInvalidDefault(t *testing.T) {
+	// Test absent case
+	var limit int
+	err := v.Validate([]*v.Value{
+		{Result: &limit, Name: "limit", Default: "ten"},
+	})
+	assert.True(t, errors.Is(err, v.ErrInvalidDefault))
+
+	var fieldErr *v.FieldError
+	assert.False(t, errors.As(err, &fieldErr))
+
+	// Test present case, where the bad default isn't used
+	err = v.Validate([]*v.Value{
+		{Result: &limit, Name: "limit", Input: "abc", Default: "ten"},
+	})
+	assert.True(t, errors.As(err, &fieldErr))
+
+	// Test strict case
+	assert.Panics(t, func() {
+		v.New(v.WithStrict()).Validate([]*v.Value{
+			{Result: &limit, Name: "limit", Default: "ten"},
+		})
+	})
+}
+
+// TestCheck tests that misconfigured Values are reported without validating inputs
+func TestCheck(t *testing.T) {
+	type Cat struct{ name string }
+	var limit int
+	var cat Cat
+
+	// Test success case
+	values := []*v.Value{
+		{Result: &limit, Name: "limit", Default: "10"},
+		{Result: &limit, Name: "limit", Input: "abc", DefaultValue: 10},
+	}
+	assert.Nil(t, v.Check(values))
+	assert.Equal(t, 0, limit)
+
+	// Test invalid default case
+	err := v.Check([]*v.Value{
+		{Result: &limit, Name: "limit", Default: "ten"},
+	})
+	assert.True(t, errors.Is(err, v.ErrInvalidDefault))
+
+	// Test invalid typed default case
+	err = v.Check([]*v.Value{
+		{Result: &limit, Name: "limit", DefaultValue: int64(10)},
+	})
+	assert.True(t, errors.Is(err, v.ErrInvalidDefault))
+
+	// Test unsupported type case
+	err = v.Check([]*v.Value{
+		{Result: &cat, Name: "cat"},
+	})
+	assert.True(t, errors.Is(err, v.ErrUnsupportedType))
+
+	// Test nil Result case, with a custom TypeHandler and Default
+	err = v.Check([]*v.Value{
+		{Name: "cat", Default: "rae", TypeHandler: func(input string, value *v.Value) error {
+			return nil
+		}},
+	})
+	assert.True(t, errors.Is(err, v.ErrUnsupportedType))
+}
+
+// TestRequired tests that required values must be supplied
+func TestRequired(t *testing.T) {
+	// Test success case
+	var id int
+	err := v.Validate([]*v.Value{
+		{Result: &id, Name: "id", Input: "12", Required: true},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 12, id)
+
+	// Test absent case
+	var absentId int
+	err = v.Validate([]*v.Value{
+		{Result: &absentId, Name: "id", Required: true},
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, "Missing `id` parameter", err.Error())
+
+	var fieldErr *v.FieldError
+	assert.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, v.CodeMissing, fieldErr.Code)
+
+	// Test null case
+	var nullId null.Int
+	err = v.Validate([]*v.Value{
+		{Result: &nullId, Name: "id", State: v.InputNull, Required: true},
+	})
+	assert.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, v.CodeMissing, fieldErr.Code)
+
+	// Test present but empty case, which is supplied
+	var nickname string
+	err = v.Validate([]*v.Value{
+		{Result: &nickname, Name: "nickname", State: v.InputPresent, Required: true},
+	})
+	assert.Nil(t, err)
+
+	// Test type mismatch case, which isn't missing
+	var badId int
+	err = v.Validate([]*v.Value{
+		{Result: &badId, Name: "id", Input: "abc", Required: true},
+	})
+	assert.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, v.CodeTypeMismatch, fieldErr.Code)
+}
+
+// TestOptional tests that optional values that aren't supplied leave the Result untouched
+func TestOptional(t *testing.T) {
+	// Test absent case
+	name := "unchanged"
+	limit := 5
+	err := v.Validate([]*v.Value{
+		{Result: &name, Name: "name", Optional: true, Rules: []v.Rule{IsSet}},
+		{Result: &limit, Name: "limit", Optional: true},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "unchanged", name)
+	assert.Equal(t, 5, limit)
+
+	// Test default case
+	err = v.Validate([]*v.Value{
+		{Result: &limit, Name: "limit", Optional: true, Default: "10"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 10, limit)
+
+	// Test present case
+	err = v.Validate([]*v.Value{
+		{Result: &limit, Name: "limit", Optional: true, Input: "20"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 20, limit)
+}
+
+// TestValidateContext tests that context rules and handlers receive the context, and that validation stops when it's done
+func TestValidateContext(t *testing.T) {
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, "taken")
+	notTaken := func(ctx context.Context, name string, input string) error {
+		if input == ctx.Value(key{}) {
+			return fmt.Errorf("`%v` is taken", name)
+		}
+		return nil
+	}
+
+	// Test context rule case
+	var username string
+	err := v.ValidateContext(ctx, []*v.Value{
+		{Result: &username, Name: "username", Input: "brandon", ContextRules: []v.ContextRule{notTaken}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "brandon", username)
+
+	// Test failing context rule case
+	err = v.ValidateContext(ctx, []*v.Value{
+		{Result: &username, Name: "username", Input: "taken", ContextRules: []v.ContextRule{notTaken}},
+	})
+	var fieldErr *v.FieldError
+	assert.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, v.CodeRuleFailed, fieldErr.Code)
+	assert.Equal(t, "`username` is taken", err.Error())
+
+	// Test context type handler case
+	var suffixed string
+	err = v.ValidateContext(ctx, []*v.Value{
+		{Result: &suffixed, Name: "suffixed", Input: "not", ContextTypeHandler: func(ctx context.Context, input string, value *v.Value) error {
+			*value.Result.(*string) = input + "-" + ctx.Value(key{}).(string)
+			return nil
+		}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "not-taken", suffixed)
+
+	// Test canceled case, where nothing is validated
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	id := 0
+	err = v.ValidateContext(canceled, []*v.Value{
+		{Result: &id, Name: "id", Input: "10"},
+	})
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Equal(t, 0, id)
+
+	// Test canceled mid validation case, where later rules and values don't run
+	canceled, cancel = context.WithCancel(context.Background())
+	calls := 0
+	cancelling := func(ctx context.Context, name string, input string) error {
+		calls++
+		cancel()
+		return nil
+	}
+	err = v.ValidateContext(canceled, []*v.Value{
+		{Result: &username, Name: "username", Input: "a", ContextRules: []v.ContextRule{cancelling, cancelling}},
+		{Result: &id, Name: "id", Input: "10"},
+	})
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, 0, id)
+}
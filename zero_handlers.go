@@ -0,0 +1,104 @@
+package validator
+
+import (
+	"gopkg.in/guregu/null.v3/zero"
+)
+
+func zeroIntHandler(input string, value *Value) error {
+	// Cast
+	zeroInt := value.Result.(*zero.Int)
+
+	// Check for empty
+	if len(input) == 0 {
+		*zeroInt = zero.Int{}
+		return nil
+	}
+
+	// Get int64
+	res, err := parseInt(input, value, 64, "an int64")
+	if err != nil {
+		return err
+	}
+
+	// Update zero.Int, which is null if zero
+	*zeroInt = zero.IntFrom(res)
+	return nil
+}
+
+func zeroStringHandler(input string, value *Value) error {
+	err := checkJSONKind(input, value, JSONString, "a string")
+	if err != nil {
+		return err
+	}
+	*value.Result.(*zero.String) = zero.StringFrom(input)
+	return nil
+}
+
+func zeroFloatHandler(input string, value *Value) error {
+	// Cast
+	zeroFloat := value.Result.(*zero.Float)
+
+	// Check for empty
+	if len(input) == 0 {
+		*zeroFloat = zero.Float{}
+		return nil
+	}
+
+	// Get float64
+	res, err := parseFloat(input, value, 64, "a float64")
+	if err != nil {
+		return err
+	}
+
+	// Update zero.Float, which is null if zero
+	*zeroFloat = zero.FloatFrom(res)
+	return nil
+}
+
+func zeroBoolHandler(input string, value *Value) error {
+	// Cast
+	zeroBool := value.Result.(*zero.Bool)
+
+	// Check for a checkbox, which is true when present
+	if value.Checkbox {
+		*zeroBool = zero.BoolFrom(value.Presence() == InputPresent)
+		return nil
+	}
+
+	// Check for empty
+	if len(input) == 0 {
+		*zeroBool = zero.Bool{}
+		return nil
+	}
+
+	// Get bool
+	res, err := parseBool(input, value)
+	if err != nil {
+		return err
+	}
+
+	// Update zero.Bool, which is null if false
+	*zeroBool = zero.BoolFrom(res)
+	return nil
+}
+
+func zeroTimeHandler(input string, value *Value) error {
+	// Cast
+	zeroTime := value.Result.(*zero.Time)
+
+	// Check for empty
+	if len(input) == 0 {
+		*zeroTime = zero.Time{}
+		return nil
+	}
+
+	// Get time.Time
+	res, err := parseTime(input, value)
+	if err != nil {
+		return err
+	}
+
+	// Update zero.Time, which is null if the zero time
+	*zeroTime = zero.TimeFrom(res)
+	return nil
+}
@@ -0,0 +1,175 @@
+package validator_test
+
+import (
+	"testing"
+	"time"
+
+	v "github.com/go-carrot/validator"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/guregu/null.v3/zero"
+)
+
+// TestZeroInt tests handling of a zero.Int as the result
+func TestZeroInt(t *testing.T) {
+	// Test success case
+	var id zero.Int
+	err := v.Validate([]*v.Value{
+		{Result: &id, Name: "id", Input: "12"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(12), id.Int64)
+	assert.True(t, id.Valid)
+
+	// Test zero case
+	zeroId := zero.IntFrom(5)
+	err = v.Validate([]*v.Value{
+		{Result: &zeroId, Name: "id", Input: "0"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), zeroId.Int64)
+	assert.False(t, zeroId.Valid)
+
+	// Test empty case
+	emptyId := zero.IntFrom(5)
+	err = v.Validate([]*v.Value{
+		{Result: &emptyId, Name: "id", Input: ""},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), emptyId.Int64)
+	assert.False(t, emptyId.Valid)
+
+	// Test failure case
+	var failureId zero.Int
+	err = v.Validate([]*v.Value{
+		{Result: &failureId, Name: "id", Input: "12a"},
+	})
+	assert.NotNil(t, err)
+	assert.False(t, failureId.Valid)
+}
+
+// TestZeroString tests handling of a zero.String as the result
+func TestZeroString(t *testing.T) {
+	// Test success case
+	var slug zero.String
+	err := v.Validate([]*v.Value{
+		{Result: &slug, Name: "slug", Input: "hello"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "hello", slug.String)
+	assert.True(t, slug.Valid)
+
+	// Test empty case
+	emptySlug := zero.StringFrom("hello")
+	err = v.Validate([]*v.Value{
+		{Result: &emptySlug, Name: "slug", Input: ""},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "", emptySlug.String)
+	assert.False(t, emptySlug.Valid)
+}
+
+// TestZeroFloat tests handling of a zero.Float as the result
+func TestZeroFloat(t *testing.T) {
+	// Test success case
+	var price zero.Float
+	err := v.Validate([]*v.Value{
+		{Result: &price, Name: "price", Input: "12.8"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, float64(12.8), price.Float64)
+	assert.True(t, price.Valid)
+
+	// Test zero case
+	zeroPrice := zero.FloatFrom(1.5)
+	err = v.Validate([]*v.Value{
+		{Result: &zeroPrice, Name: "price", Input: "0.0"},
+	})
+	assert.Nil(t, err)
+	assert.False(t, zeroPrice.Valid)
+
+	// Test empty case
+	emptyPrice := zero.FloatFrom(1.5)
+	err = v.Validate([]*v.Value{
+		{Result: &emptyPrice, Name: "price", Input: ""},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, float64(0), emptyPrice.Float64)
+	assert.False(t, emptyPrice.Valid)
+
+	// Test failure case
+	var failurePrice zero.Float
+	err = v.Validate([]*v.Value{
+		{Result: &failurePrice, Name: "price", Input: "12.8a"},
+	})
+	assert.NotNil(t, err)
+	assert.False(t, failurePrice.Valid)
+}
+
+// TestZeroBool tests handling of a zero.Bool as the result
+func TestZeroBool(t *testing.T) {
+	// Test success case
+	var someBool zero.Bool
+	err := v.Validate([]*v.Value{
+		{Result: &someBool, Name: "some_bool", Input: "true"},
+	})
+	assert.Nil(t, err)
+	assert.True(t, someBool.Bool)
+	assert.True(t, someBool.Valid)
+
+	// Test false case
+	falseBool := zero.BoolFrom(true)
+	err = v.Validate([]*v.Value{
+		{Result: &falseBool, Name: "some_bool", Input: "false"},
+	})
+	assert.Nil(t, err)
+	assert.False(t, falseBool.Bool)
+	assert.False(t, falseBool.Valid)
+
+	// Test empty case
+	emptyBool := zero.BoolFrom(true)
+	err = v.Validate([]*v.Value{
+		{Result: &emptyBool, Name: "some_bool", Input: ""},
+	})
+	assert.Nil(t, err)
+	assert.False(t, emptyBool.Bool)
+	assert.False(t, emptyBool.Valid)
+
+	// Test failure case
+	var someOtherBool zero.Bool
+	err = v.Validate([]*v.Value{
+		{Result: &someOtherBool, Name: "some_other_bool", Input: "12.8a"},
+	})
+	assert.NotNil(t, err)
+	assert.False(t, someOtherBool.Valid)
+}
+
+// TestZeroTime tests handling of a zero.Time as the result
+func TestZeroTime(t *testing.T) {
+	// Test success case
+	var successTime zero.Time
+	err := v.Validate([]*v.Value{
+		{Result: &successTime, Name: "time", Input: "2012-11-01T22:08:41+00:00"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, successTime.Time.Year(), 2012)
+	assert.Equal(t, successTime.Time.Month(), time.November)
+	assert.Equal(t, successTime.Time.Day(), 1)
+	assert.True(t, successTime.Valid)
+
+	// Test empty case
+	emptyTime := zero.TimeFrom(time.Now())
+	err = v.Validate([]*v.Value{
+		{Result: &emptyTime, Name: "time", Input: ""},
+	})
+	assert.Nil(t, err)
+	assert.True(t, emptyTime.Time.IsZero())
+	assert.False(t, emptyTime.Valid)
+
+	// Test failure case
+	var errorTime zero.Time
+	err = v.Validate([]*v.Value{
+		{Result: &errorTime, Name: "time", Input: "abcd"},
+	})
+	assert.NotNil(t, err)
+	assert.False(t, errorTime.Valid)
+}